@@ -2,7 +2,10 @@ module github.com/lilwiggy/ex-act
 
 go 1.25.7
 
-require github.com/cockroachdb/apd/v3 v3.2.1
+require (
+	github.com/cockroachdb/apd/v3 v3.2.1
+	golang.org/x/time v0.14.0
+)
 
 require (
 	github.com/dolthub/maphash v0.1.0 // indirect
@@ -14,6 +17,5 @@ require (
 	github.com/sony/gobreaker v1.0.0 // indirect
 	golang.org/x/net v0.43.0 // indirect
 	golang.org/x/sys v0.35.0 // indirect
-	golang.org/x/time v0.14.0 // indirect
 	resty.dev/v3 v3.0.0-beta.6 // indirect
 )
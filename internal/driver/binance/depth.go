@@ -0,0 +1,456 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/market"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+const (
+	// depthSnapshotLimit is the number of levels requested for book snapshots.
+	depthSnapshotLimit = 1000
+
+	// depthSnapshotRetryDelay is the wait before retrying a failed or stale snapshot.
+	depthSnapshotRetryDelay = time.Second
+
+	// maxBufferedDepthUpdates bounds the diffs buffered while a snapshot is fetched.
+	// At 100ms updates this covers well over a minute of snapshot latency.
+	maxBufferedDepthUpdates = 1000
+)
+
+// GetDepth returns an order book snapshot.
+// API: GET /api/v3/depth
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#order-book
+// Weight: 5 (limit 1-100), 25 (101-500), 50 (501-1000), 250 (1001-5000)
+func (rc *RESTClient) GetDepth(ctx context.Context, symbol string, limit int) (*WSDepthSnapshot, error) {
+	var result WSDepthSnapshot
+
+	params := map[string]string{
+		"symbol": symbols.ExchangeSymbol(symbol),
+	}
+	if limit > 0 {
+		params["limit"] = strconv.Itoa(limit)
+	}
+
+	resp, err := rc.client.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(&result).
+		Get(EDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, rc.handleErrorResponse(resp)
+	}
+
+	return &result, nil
+}
+
+// DepthManager maintains local order books from the diff-depth stream.
+//
+// Synchronisation follows the documented procedure:
+//  1. Buffer diffs from the stream
+//  2. Fetch a REST snapshot; refetch if it is older than the first buffered diff
+//  3. Drop buffered diffs with u <= lastUpdateId
+//  4. The first diff applied must satisfy U <= lastUpdateId+1 <= u
+//  5. Apply every later diff in order, dropping any with u <= the book's update ID
+//
+// Every diff is checked for continuity: a diff with U greater than the book's
+// update ID + 1 means updates were lost. The book is then invalidated, a resync
+// is reported and a fresh snapshot is fetched automatically. Books are also
+// resynced when the stream reconnects.
+//
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#how-to-manage-a-local-order-book-correctly
+type DepthManager struct {
+	fetch func(ctx context.Context, symbol string) (*WSDepthSnapshot, error)
+
+	onBook   func(orderBook *domain.OrderBook)
+	onResync func(symbol string, reason error)
+	onError  func(err error)
+
+	resyncs atomic.Int64
+
+	books map[string]*depthBook // Keyed by exchange symbol (e.g., "BTCUSDT")
+	mu    sync.RWMutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// depthBook is the sync state for one symbol.
+type depthBook struct {
+	symbol string // Exchange symbol
+	book   *market.Book
+
+	mu       sync.Mutex
+	buffer   []*WSDepthUpdate
+	synced   bool // False until the first sync and while resyncing
+	fetching bool
+	removed  bool
+	resyncs  int64
+	events   []depthEvent // Callbacks queued under mu, delivered by flush
+	draining bool         // A goroutine is delivering events
+}
+
+// depthEvent is a callback produced while a book is locked.
+// Exactly one field is set.
+type depthEvent struct {
+	book   *domain.OrderBook // Snapshot for onBook
+	resync error             // Reason for onResync
+	err    error             // Error for onError
+}
+
+// NewDepthManager creates a depth manager that fetches snapshots from rest.
+func NewDepthManager(rest *RESTClient) *DepthManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DepthManager{
+		fetch: func(ctx context.Context, symbol string) (*WSDepthSnapshot, error) {
+			return rest.GetDepth(ctx, symbol, depthSnapshotLimit)
+		},
+		books:  make(map[string]*depthBook),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// OnBook sets the callback for consistent book snapshots.
+// It is called after every applied diff and after each (re)sync.
+func (m *DepthManager) OnBook(fn func(orderBook *domain.OrderBook)) {
+	m.onBook = fn
+}
+
+// OnResync sets the callback for invalidated books.
+// symbol is normalized; reason is a *errors.SequenceGapError.
+func (m *DepthManager) OnResync(fn func(symbol string, reason error)) {
+	m.onResync = fn
+}
+
+// OnError sets the callback for snapshot and sync failures.
+func (m *DepthManager) OnError(fn func(err error)) {
+	m.onError = fn
+}
+
+// Track starts maintaining a book for symbol.
+// The book syncs once the first diff arrives.
+func (m *DepthManager) Track(symbol string) {
+	key := symbols.ExchangeSymbol(symbol)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.books[key]; ok {
+		return
+	}
+	m.books[key] = &depthBook{
+		symbol: key,
+		book:   market.NewBook(exchange, symbols.Normalize(key)),
+	}
+}
+
+// Untrack stops maintaining the book for symbol.
+func (m *DepthManager) Untrack(symbol string) {
+	key := symbols.ExchangeSymbol(symbol)
+
+	m.mu.Lock()
+	b, ok := m.books[key]
+	delete(m.books, key)
+	m.mu.Unlock()
+
+	if ok {
+		b.mu.Lock()
+		b.removed = true
+		b.events = nil // Undelivered updates of an untracked book are stale
+		b.mu.Unlock()
+	}
+}
+
+// Book returns the maintained book for symbol.
+// Returns false if the symbol is not tracked or not yet synced.
+func (m *DepthManager) Book(symbol string) (*market.Book, bool) {
+	m.mu.RLock()
+	b, ok := m.books[symbols.ExchangeSymbol(symbol)]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.synced {
+		return nil, false
+	}
+	return b.book, true
+}
+
+// Resyncs returns the number of resyncs across all books since creation.
+func (m *DepthManager) Resyncs() int64 {
+	return m.resyncs.Load()
+}
+
+// SymbolResyncs returns the number of resyncs of symbol's book since it was tracked.
+func (m *DepthManager) SymbolResyncs(symbol string) int64 {
+	m.mu.RLock()
+	b, ok := m.books[symbols.ExchangeSymbol(symbol)]
+	m.mu.RUnlock()
+	if !ok {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.resyncs
+}
+
+// ResyncAll invalidates every synced book, e.g. after a stream reconnect.
+// Books sync again from a fresh snapshot once diffs resume.
+func (m *DepthManager) ResyncAll(message string) {
+	m.mu.RLock()
+	books := make([]*depthBook, 0, len(m.books))
+	for _, b := range m.books {
+		books = append(books, b)
+	}
+	m.mu.RUnlock()
+
+	for _, b := range books {
+		m.resync(b, message)
+	}
+}
+
+// Resync invalidates symbol's book if it is synced.
+func (m *DepthManager) Resync(symbol, message string) {
+	m.mu.RLock()
+	b, ok := m.books[symbols.ExchangeSymbol(symbol)]
+	m.mu.RUnlock()
+
+	if ok {
+		m.resync(b, message)
+	}
+}
+
+// resync invalidates a synced book.
+func (m *DepthManager) resync(b *depthBook, message string) {
+	defer m.flush(b)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.synced {
+		m.invalidate(b, errors.NewSequenceGapError(exchange, symbols.Normalize(b.symbol), b.book.LastUpdateID()+1, 0, message))
+	}
+}
+
+// Close stops pending snapshot fetches.
+func (m *DepthManager) Close() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// HandleUpdate applies a diff-depth event to its book.
+// Diffs for untracked symbols are ignored.
+func (m *DepthManager) HandleUpdate(update *WSDepthUpdate) {
+	m.mu.RLock()
+	b, ok := m.books[update.Symbol]
+	m.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	defer m.flush(b)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.synced {
+		m.buffer(b, update)
+		return
+	}
+
+	last := b.book.LastUpdateID()
+	if update.FinalUpdateID <= last {
+		return
+	}
+	if update.FirstUpdateID > last+1 {
+		m.invalidate(b, errors.NewSequenceGapError(exchange, symbols.Normalize(b.symbol), last+1, update.FirstUpdateID, "diff does not follow book"))
+		m.buffer(b, update)
+		return
+	}
+	if err := m.apply(b, update); err != nil {
+		b.events = append(b.events, depthEvent{err: err})
+		return
+	}
+	m.publish(b)
+}
+
+// buffer queues a diff until the book syncs and starts a snapshot fetch if needed.
+// Callers must hold b.mu.
+func (m *DepthManager) buffer(b *depthBook, update *WSDepthUpdate) {
+	b.buffer = append(b.buffer, update)
+	if len(b.buffer) > maxBufferedDepthUpdates {
+		b.buffer = b.buffer[1:]
+	}
+	if !b.fetching {
+		b.fetching = true
+		m.wg.Go(func() { m.sync(b) })
+	}
+}
+
+// invalidate marks a book as out of sync and queues the resync report.
+// Callers must hold b.mu.
+func (m *DepthManager) invalidate(b *depthBook, reason error) {
+	b.synced = false
+	b.buffer = nil
+	b.book.Clear()
+	b.resyncs++
+	m.resyncs.Add(1)
+
+	b.events = append(b.events, depthEvent{resync: reason})
+}
+
+// sync fetches a snapshot and applies buffered diffs until the book is synced.
+// It stops as soon as the book is untracked, so failing fetches are not
+// retried for a symbol no longer wanted.
+func (m *DepthManager) sync(b *depthBook) {
+	for {
+		b.mu.Lock()
+		removed := b.removed
+		if removed {
+			b.fetching = false
+		}
+		b.mu.Unlock()
+		if removed {
+			return
+		}
+
+		snapshot, err := m.fetch(m.ctx, b.symbol)
+		if m.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			m.reportError(errors.NewExchangeError(exchange, "depth_snapshot", "failed to fetch snapshot for "+b.symbol, err))
+		} else if m.syncFromSnapshot(b, snapshot) {
+			return
+		}
+
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(depthSnapshotRetryDelay):
+		}
+	}
+}
+
+// syncFromSnapshot resets the book from snapshot and replays buffered diffs.
+// Returns false if the snapshot cannot be used and must be refetched.
+func (m *DepthManager) syncFromSnapshot(b *depthBook, snapshot *WSDepthSnapshot) bool {
+	defer m.flush(b)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.removed {
+		b.fetching = false
+		return true
+	}
+
+	// Snapshot older than the first buffered diff: diffs in between were never seen
+	if len(b.buffer) > 0 && snapshot.LastUpdateID < b.buffer[0].FirstUpdateID {
+		return false
+	}
+
+	orderBook, err := snapshot.ToDomain(exchange, b.symbol)
+	if err != nil {
+		b.events = append(b.events, depthEvent{err: errors.NewExchangeError(exchange, "depth_snapshot", "failed to parse snapshot for "+b.symbol, err)})
+		return false
+	}
+	b.book.Reset(orderBook.Bids, orderBook.Asks, snapshot.LastUpdateID, orderBook.Timestamp)
+
+	for _, update := range b.buffer {
+		if update.FinalUpdateID <= b.book.LastUpdateID() {
+			continue
+		}
+		if update.FirstUpdateID > b.book.LastUpdateID()+1 {
+			// Buffered diffs do not follow the snapshot; start over
+			b.buffer = b.buffer[:0]
+			return false
+		}
+		if err := m.apply(b, update); err != nil {
+			b.events = append(b.events, depthEvent{err: err})
+			return false
+		}
+	}
+
+	b.buffer = nil
+	b.synced = true
+	b.fetching = false
+	m.publish(b)
+	return true
+}
+
+// apply merges a diff into the book.
+// Callers must hold b.mu.
+func (m *DepthManager) apply(b *depthBook, update *WSDepthUpdate) error {
+	bids, asks, err := update.ToDomain()
+	if err != nil {
+		return errors.NewExchangeError(exchange, "depth_update", "failed to parse diff for "+b.symbol, err)
+	}
+	b.book.Apply(bids, asks, update.FinalUpdateID, time.UnixMilli(update.EventTime))
+	return nil
+}
+
+// publish queues a consistent snapshot of the book.
+// Callers must hold b.mu.
+func (m *DepthManager) publish(b *depthBook) {
+	if m.onBook == nil {
+		return
+	}
+	b.events = append(b.events, depthEvent{book: b.book.Snapshot()})
+}
+
+// flush delivers the queued events of a book in order, without holding b.mu,
+// so callbacks may call back into the manager (e.g., Untrack or Resync).
+// One goroutine delivers at a time; events queued meanwhile, including by
+// the callbacks, are delivered by it.
+func (m *DepthManager) flush(b *depthBook) {
+	b.mu.Lock()
+	if b.draining {
+		b.mu.Unlock()
+		return
+	}
+	b.draining = true
+	for len(b.events) > 0 {
+		ev := b.events[0]
+		b.events = b.events[1:]
+		b.mu.Unlock()
+
+		switch {
+		case ev.book != nil:
+			if m.onBook != nil {
+				m.onBook(ev.book)
+			}
+		case ev.resync != nil:
+			if m.onResync != nil {
+				m.onResync(symbols.Normalize(b.symbol), ev.resync)
+			}
+		case ev.err != nil:
+			m.reportError(ev.err)
+		}
+
+		b.mu.Lock()
+	}
+	b.events = nil
+	b.draining = false
+	b.mu.Unlock()
+}
+
+// reportError forwards a sync error to the error callback.
+func (m *DepthManager) reportError(err error) {
+	if m.onError != nil {
+		m.onError(err)
+	}
+}
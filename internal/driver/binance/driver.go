@@ -0,0 +1,414 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/ratelimit"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+func init() {
+	driver.Register(exchange, NewDriver)
+}
+
+// symbols converts between exchange and normalized symbols using the listed
+// base and quote assets. It is filled whenever exchange info is loaded.
+var symbols = domain.Symbols(exchange)
+
+// Driver adapts the Binance REST and WebSocket clients to driver.Driver.
+// Market data uses combined streams sharded across a connection pool; order
+// books are maintained locally from the diff-depth stream and REST snapshots. When credentials are configured,
+// order and balance updates are delivered from the listenKey user data stream.
+//
+// With the WebSocket order transport, orders are placed, cancelled and queried
+// over the WebSocket API. With an Ed25519 key, user data is then also
+// subscribed on that connection instead of a listenKey stream.
+type Driver struct {
+	rest     *RESTClient
+	ws       *WSPool
+	depth    *DepthManager
+	userData *UserDataStream // nil without credentials, or when user data comes from wsAPI
+	wsAPI    *WSAPIClient    // nil unless the WebSocket order transport is selected
+
+	onError func(err error)
+
+	// Lifecycle of background loads retried after Connect
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDriver creates a Binance driver from driver configuration.
+func NewDriver(cfg driver.Config) (driver.Driver, error) {
+	// One gate per driver: limits and bans apply to the whole IP
+	gate := ratelimit.NewGate()
+
+	rest, err := NewRESTClient(Config{
+		APIKey:     cfg.APIKey,
+		APISecret:  cfg.APISecret,
+		KeyType:    cfg.KeyType,
+		Timeout:    cfg.Timeout,
+		MaxWeight:  cfg.MaxWeight,
+		RecvWindow: cfg.RecvWindow,
+		Clock:      cfg.Clock,
+		Testnet:    cfg.Testnet,
+		Gate:       gate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	wsCfg := WSConfig{
+		Testnet:      cfg.Testnet,
+		Gate:         gate,
+		PingInterval: cfg.PingInterval,
+		Reconnect: ReconnectConfig{
+			InitialDelay: cfg.ReconnectDelay,
+			MaxDelay:     cfg.MaxReconnectWait,
+			MaxAttempts:  0, // Infinite
+			Jitter:       0.1,
+		},
+	}
+
+	d := &Driver{
+		rest:  rest,
+		ws:    NewWSPool(wsCfg, cfg.MaxStreamsPerConn),
+		depth: NewDepthManager(rest),
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	d.ws.OnDepthUpdate(d.depth.HandleUpdate)
+
+	switch cfg.OrderTransport {
+	case "", driver.OrderTransportREST:
+	case driver.OrderTransportWebSocket:
+		if cfg.APIKey == "" || cfg.APISecret == "" {
+			return nil, errors.NewValidationError("order_transport", cfg.OrderTransport, "requires API credentials")
+		}
+		d.wsAPI = NewWSAPIClient(rest, wsCfg)
+	default:
+		return nil, errors.NewValidationError("order_transport", cfg.OrderTransport, "must be rest or websocket")
+	}
+
+	// session.logon, and so the WebSocket API user data subscription, needs an Ed25519 key
+	if cfg.APIKey != "" && cfg.APISecret != "" && !d.userDataOnWSAPI() {
+		d.userData = NewUserDataStream(rest, wsCfg)
+	}
+
+	return d, nil
+}
+
+// userDataOnWSAPI reports whether user data is subscribed on the WebSocket API.
+func (d *Driver) userDataOnWSAPI() bool {
+	return d.wsAPI != nil && d.rest.signer.KeyType() == driver.KeyTypeEd25519
+}
+
+// Name returns the exchange name.
+func (d *Driver) Name() string {
+	return exchange
+}
+
+// Connect establishes the market data WebSocket and, if configured, the
+// WebSocket API connection and the user data stream.
+// Rate limits and symbols are loaded from exchangeInfo in the background,
+// retrying with backoff until it succeeds; until then the default rate
+// limits apply and symbols are normalized heuristically.
+//
+// Only a market data failure is returned. If the WebSocket API or the user
+// data stream cannot be started, the failure is reported to the error
+// callback and startup is retried in the background with backoff.
+func (d *Driver) Connect(ctx context.Context) error {
+	d.wg.Go(func() {
+		d.retry("exchange_info", d.loadExchangeInfo(d.ctx), d.loadExchangeInfo)
+	})
+
+	if err := d.ws.Connect(); err != nil {
+		return err
+	}
+	if err := d.connectAccount(ctx); err != nil {
+		d.wg.Go(func() {
+			d.retry("connect_account", err, d.connectAccount)
+		})
+	}
+	return nil
+}
+
+// connectAccount starts the WebSocket API connection and the user data
+// subscription, skipping the steps already done by an earlier attempt.
+// Once started, each connection reconnects on its own.
+func (d *Driver) connectAccount(ctx context.Context) error {
+	if d.wsAPI != nil {
+		if !d.wsAPI.started.Load() {
+			if err := d.wsAPI.Connect(ctx); err != nil {
+				return err
+			}
+		}
+		if d.userDataOnWSAPI() && !d.wsAPI.userData.Load() {
+			if err := d.wsAPI.SubscribeUserData(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if d.userData != nil && !d.userData.Started() {
+		return d.userData.Start(ctx)
+	}
+	return nil
+}
+
+// loadExchangeInfo loads exchangeInfo, which sizes the rate limit buckets
+// and registers the listed symbols.
+func (d *Driver) loadExchangeInfo(ctx context.Context) error {
+	_, err := d.GetExchangeInfo(ctx, driver.ExchangeInfoQuery{})
+	return err
+}
+
+// retry calls fn again until it succeeds or the driver is closed, backing
+// off between attempts. err is the result of the first attempt; failures
+// are reported to the error callback.
+func (d *Driver) retry(operation string, err error, fn func(ctx context.Context) error) {
+	for attempt := 1; err != nil; attempt++ {
+		if d.ctx.Err() != nil {
+			return
+		}
+		d.reportError(errors.NewExchangeError(exchange, operation, "failed, retrying", err))
+
+		timer := time.NewTimer(reconnectDelay(DefaultReconnectConfig(), attempt))
+		select {
+		case <-d.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		err = fn(d.ctx)
+	}
+}
+
+// reportError forwards an error to the error callback.
+func (d *Driver) reportError(err error) {
+	if d.onError != nil {
+		d.onError(err)
+	}
+}
+
+// Close closes the WebSocket and REST clients.
+// The user data stream is stopped first so its listenKey can still be deleted.
+func (d *Driver) Close() error {
+	d.cancel()
+	d.wg.Wait()
+
+	if d.userData != nil {
+		d.userData.Stop()
+	}
+	if d.wsAPI != nil {
+		d.wsAPI.Close()
+	}
+	d.depth.Close()
+	err := d.ws.Close()
+	d.rest.Close()
+	return err
+}
+
+// IsConnected returns true if every market data connection is connected.
+func (d *Driver) IsConnected() bool {
+	return d.ws.IsConnected()
+}
+
+// Backoff reports whether traffic is held back after a 429 or 418.
+func (d *Driver) Backoff() driver.BackoffStatus {
+	status := d.rest.Gate().Status()
+	return driver.BackoffStatus{
+		Active: status.Closed,
+		Banned: status.Banned,
+		Until:  status.Until,
+		Reason: status.Reason,
+	}
+}
+
+// Ping tests REST connectivity.
+func (d *Driver) Ping(ctx context.Context) error {
+	return d.rest.Ping(ctx)
+}
+
+// GetServerTime returns the server time in milliseconds.
+func (d *Driver) GetServerTime(ctx context.Context) (int64, error) {
+	return d.rest.GetServerTime(ctx)
+}
+
+// GetExchangeInfo returns exchange trading rules as domain types.
+// Listed symbols are added to the symbol registry.
+func (d *Driver) GetExchangeInfo(ctx context.Context, query driver.ExchangeInfoQuery) (*domain.ExchangeInfo, error) {
+	info, err := d.rest.GetExchangeInfo(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result := info.ToDomain()
+	symbols.Register(result.Symbols...)
+	return result, nil
+}
+
+// GetKlines returns historical klines, paging through long ranges.
+func (d *Driver) GetKlines(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	return d.rest.GetKlines(ctx, symbol, interval, start, end)
+}
+
+// PlaceOrder submits a new order over the configured order transport.
+func (d *Driver) PlaceOrder(ctx context.Context, req *domain.OrderRequest) (*domain.Order, error) {
+	if d.wsAPI != nil {
+		return d.wsAPI.PlaceOrder(ctx, req)
+	}
+	return d.rest.PlaceOrder(ctx, req)
+}
+
+// CancelOrder cancels an order over the configured order transport.
+func (d *Driver) CancelOrder(ctx context.Context, req *domain.CancelRequest) (*domain.Order, error) {
+	if d.wsAPI != nil {
+		return d.wsAPI.CancelOrder(ctx, req)
+	}
+	return d.rest.CancelOrder(ctx, req)
+}
+
+// CancelAllOrders cancels all open orders for a symbol.
+func (d *Driver) CancelAllOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	return d.rest.CancelAllOrders(ctx, symbol)
+}
+
+// GetOrder returns a single order by exchange order ID over the configured order transport.
+func (d *Driver) GetOrder(ctx context.Context, symbol, orderID string) (*domain.Order, error) {
+	if d.wsAPI != nil {
+		return d.wsAPI.GetOrder(ctx, symbol, orderID)
+	}
+	return d.rest.GetOrder(ctx, symbol, orderID)
+}
+
+// GetOpenOrders returns open orders.
+func (d *Driver) GetOpenOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	return d.rest.GetOpenOrders(ctx, symbol)
+}
+
+// GetAllOrders returns order history for a symbol.
+func (d *Driver) GetAllOrders(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.Order, error) {
+	return d.rest.GetAllOrders(ctx, symbol, startTime, endTime, limit)
+}
+
+// GetMyTrades returns account fills for a symbol.
+func (d *Driver) GetMyTrades(ctx context.Context, symbol string, since time.Time, limit int) ([]domain.Trade, error) {
+	return d.rest.GetMyTrades(ctx, symbol, since, limit)
+}
+
+// GetBalances returns all non-empty balances from the account endpoint.
+func (d *Driver) GetBalances(ctx context.Context) ([]domain.Balance, error) {
+	account, err := d.rest.GetAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := time.UnixMilli(account.UpdateTime)
+	balances := make([]domain.Balance, 0, len(account.Balances))
+	for _, b := range account.Balances {
+		if domain.IsZero(b.Free) && domain.IsZero(b.Locked) {
+			continue
+		}
+		balances = append(balances, domain.Balance{
+			Exchange:  exchange,
+			Asset:     b.Asset,
+			Free:      b.Free,
+			Locked:    b.Locked,
+			Timestamp: updated,
+		})
+	}
+	return balances, nil
+}
+
+// SetCallbacks wires stream callbacks into the WebSocket client.
+func (d *Driver) SetCallbacks(cb driver.Callbacks) {
+	d.ws.OnTicker(cb.OnTicker)
+	d.ws.OnOrderBook(cb.OnOrderBook)
+	d.ws.OnTrade(cb.OnTrade)
+	d.ws.OnKline(cb.OnKline)
+	d.ws.OnConnect(cb.OnConnect)
+	d.ws.OnError(cb.OnError)
+	d.ws.OnDisconnect(func(streams []string, err error) {
+		// Diffs sent while the shard was disconnected are lost
+		for _, stream := range streams {
+			if ParseStreamType(stream) == "depth" {
+				d.depth.Resync(ParseStreamSymbol(stream), "stream disconnected")
+			}
+		}
+		if cb.OnDisconnect != nil {
+			cb.OnDisconnect(err)
+		}
+	})
+	d.depth.OnBook(cb.OnOrderBook)
+	d.depth.OnResync(cb.OnBookResync)
+	d.depth.OnError(cb.OnError)
+	d.rest.Gate().OnClose(cb.OnError)
+	d.onError = cb.OnError
+
+	if d.userData != nil {
+		d.userData.OnOrder(cb.OnOrder)
+		d.userData.OnBalance(cb.OnBalance)
+		d.userData.OnBalanceDelta(cb.OnBalanceDelta)
+		d.userData.OnConnect(cb.OnUserDataConnect)
+		d.userData.OnDisconnect(cb.OnUserDataDisconnect)
+		d.userData.OnError(cb.OnError)
+	}
+	if d.wsAPI != nil {
+		d.wsAPI.OnOrder(cb.OnOrder)
+		d.wsAPI.OnBalance(cb.OnBalance)
+		d.wsAPI.OnBalanceDelta(cb.OnBalanceDelta)
+		d.wsAPI.OnError(cb.OnError)
+		if d.userDataOnWSAPI() {
+			d.wsAPI.OnConnect(cb.OnUserDataConnect)
+			d.wsAPI.OnDisconnect(cb.OnUserDataDisconnect)
+		}
+	}
+}
+
+// Subscribe adds a stream subscription.
+// Order book subscriptions start local book maintenance for the symbol.
+func (d *Driver) Subscribe(sub driver.Subscription) error {
+	stream, err := streamName(sub)
+	if err != nil {
+		return err
+	}
+	if sub.Channel == driver.ChannelOrderBook {
+		d.depth.Track(sub.Symbol)
+	}
+	return d.ws.Subscribe(stream)
+}
+
+// Unsubscribe removes a stream subscription.
+func (d *Driver) Unsubscribe(sub driver.Subscription) error {
+	stream, err := streamName(sub)
+	if err != nil {
+		return err
+	}
+	if sub.Channel == driver.ChannelOrderBook {
+		d.depth.Untrack(sub.Symbol)
+	}
+	return d.ws.Unsubscribe(stream)
+}
+
+// streamName maps a driver subscription to a Binance stream name.
+func streamName(sub driver.Subscription) (string, error) {
+	sb := NewStreamBuilder(sub.Symbol)
+	switch sub.Channel {
+	case driver.ChannelTicker:
+		return sb.Ticker(), nil
+	case driver.ChannelOrderBook:
+		return sb.Depth(), nil
+	case driver.ChannelTrade:
+		return sb.Trade(), nil
+	case driver.ChannelKline:
+		if sub.Interval == "" {
+			return "", errors.NewValidationError("interval", sub.Interval, "interval is required for kline subscriptions")
+		}
+		return sb.Kline(sub.Interval), nil
+	default:
+		return "", errors.NewValidationError("channel", sub.Channel, "unsupported channel")
+	}
+}
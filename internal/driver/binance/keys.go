@@ -0,0 +1,168 @@
+package binance
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/lilwiggy/ex-act/pkg/driver"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for signing.
+const minRSAKeyBits = 2048
+
+// KeySigner computes the signature of a request payload with an API key's secret.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#signed-trade-and-user_data-endpoint-security
+//
+// Binance supports three key types:
+//   - HMAC: HMAC-SHA256 with a shared secret, hex-encoded
+//   - RSA: RSASSA-PKCS1-v1_5 with SHA-256, base64-encoded
+//   - Ed25519: Ed25519, base64-encoded (required for WebSocket API session logon)
+//
+// Base64 signatures contain '+', '/' and '=', which must be percent-encoded
+// in the query string; url.Values.Encode does this.
+type KeySigner interface {
+	// Sign returns the encoded signature of payload.
+	Sign(payload []byte) string
+
+	// KeyType returns the key type (driver.KeyTypeHMAC, KeyTypeRSA or KeyTypeEd25519).
+	KeyType() string
+}
+
+// NewKeySigner creates a KeySigner for a key type.
+// For HMAC, secret is the API secret. For RSA and Ed25519, secret is the
+// PKCS#8 PEM-encoded private key. An empty keyType means HMAC.
+// The key is parsed and validated here, so a bad key fails at construction
+// rather than on the first signed request.
+func NewKeySigner(keyType, secret string) (KeySigner, error) {
+	switch keyType {
+	case "", driver.KeyTypeHMAC:
+		return NewHMACSigner(secret)
+	case driver.KeyTypeRSA:
+		return NewRSASigner(secret)
+	case driver.KeyTypeEd25519:
+		return NewEd25519Signer(secret)
+	default:
+		return nil, errors.NewValidationError("key_type", keyType, "must be HMAC, RSA or ED25519")
+	}
+}
+
+// HMACSigner signs with HMAC-SHA256 and a shared secret.
+type HMACSigner struct {
+	secret []byte
+}
+
+// NewHMACSigner creates an HMAC-SHA256 signer.
+func NewHMACSigner(secret string) (*HMACSigner, error) {
+	if secret == "" {
+		return nil, errors.NewValidationError("api_secret", nil, "must not be empty")
+	}
+	return &HMACSigner{secret: []byte(secret)}, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of payload.
+func (s *HMACSigner) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// KeyType returns driver.KeyTypeHMAC.
+func (s *HMACSigner) KeyType() string {
+	return driver.KeyTypeHMAC
+}
+
+// RSASigner signs with RSASSA-PKCS1-v1_5 over SHA-256.
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSASigner creates an RSA signer from a PKCS#8 PEM private key.
+// Keys shorter than 2048 bits are rejected.
+func NewRSASigner(privateKeyPEM string) (*RSASigner, error) {
+	key, err := parsePKCS8(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.NewValidationError("api_secret", nil, fmt.Sprintf("expected an RSA private key, got %T", key))
+	}
+	if err := rsaKey.Validate(); err != nil {
+		return nil, errors.NewValidationError("api_secret", nil, "invalid RSA private key: "+err.Error())
+	}
+	if bits := rsaKey.N.BitLen(); bits < minRSAKeyBits {
+		return nil, errors.NewValidationError("api_secret", nil, fmt.Sprintf("RSA key is %d bits, need at least %d", bits, minRSAKeyBits))
+	}
+	return &RSASigner{key: rsaKey}, nil
+}
+
+// Sign returns the base64-encoded RSA signature of payload.
+func (s *RSASigner) Sign(payload []byte) string {
+	digest := sha256.Sum256(payload)
+	// Only fails for keys too small for the digest, which the constructor rules out
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+// KeyType returns driver.KeyTypeRSA.
+func (s *RSASigner) KeyType() string {
+	return driver.KeyTypeRSA
+}
+
+// Ed25519Signer signs with an Ed25519 private key.
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer creates an Ed25519 signer from a PKCS#8 PEM private key.
+func NewEd25519Signer(privateKeyPEM string) (*Ed25519Signer, error) {
+	key, err := parsePKCS8(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.NewValidationError("api_secret", nil, fmt.Sprintf("expected an Ed25519 private key, got %T", key))
+	}
+	return &Ed25519Signer{key: edKey}, nil
+}
+
+// Sign returns the base64-encoded Ed25519 signature of payload.
+func (s *Ed25519Signer) Sign(payload []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, payload))
+}
+
+// KeyType returns driver.KeyTypeEd25519.
+func (s *Ed25519Signer) KeyType() string {
+	return driver.KeyTypeEd25519
+}
+
+// parsePKCS8 decodes a PEM block and parses the PKCS#8 private key inside.
+// Errors never include key material.
+func parsePKCS8(privateKeyPEM string) (any, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.NewValidationError("api_secret", nil, "no PEM block found in private key")
+	}
+	if block.Type != "PRIVATE KEY" {
+		return nil, errors.NewValidationError("api_secret", nil, fmt.Sprintf("PEM block is %q, expected a PKCS#8 \"PRIVATE KEY\"", block.Type))
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.NewValidationError("api_secret", nil, "invalid PKCS#8 private key: "+err.Error())
+	}
+	return key, nil
+}
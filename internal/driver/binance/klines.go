@@ -0,0 +1,186 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+)
+
+// maxKlinesLimit is the maximum page size for /api/v3/klines.
+const maxKlinesLimit = 1000
+
+// GetKlines returns the klines opened between start and end, oldest first.
+// API: GET /api/v3/klines
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#kline-candlestick-data
+// Weight: 2 per page
+//
+// Ranges longer than one page (1000 klines) are walked forward one page at a
+// time. Every page waits on the weight limiter, so arbitrarily long ranges are
+// spread out to stay within the weight budget instead of failing with 429.
+// A zero end means up to the latest kline. A zero start returns only the
+// latest page ending at end.
+func (rc *RESTClient) GetKlines(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	if start.IsZero() {
+		return rc.getKlinesPage(ctx, symbol, interval, start, end)
+	}
+
+	var klines []domain.Kline
+	cursor := start
+	for end.IsZero() || !cursor.After(end) {
+		page, err := rc.getKlinesPage(ctx, symbol, interval, cursor, end)
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, page...)
+
+		if len(page) < maxKlinesLimit {
+			break
+		}
+		cursor = page[len(page)-1].OpenTime.Add(time.Millisecond)
+	}
+
+	return klines, nil
+}
+
+// getKlinesPage fetches a single page of up to maxKlinesLimit klines.
+func (rc *RESTClient) getKlinesPage(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	params := map[string]string{
+		"symbol":   symbols.ExchangeSymbol(symbol),
+		"interval": interval,
+		"limit":    strconv.Itoa(maxKlinesLimit),
+	}
+	if !start.IsZero() {
+		params["startTime"] = strconv.FormatInt(start.UnixMilli(), 10)
+	}
+	if !end.IsZero() {
+		params["endTime"] = strconv.FormatInt(end.UnixMilli(), 10)
+	}
+
+	var result []KlineResponse
+
+	resp, err := rc.client.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(&result).
+		Get(EKlines)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, rc.handleErrorResponse(resp)
+	}
+
+	klines := make([]domain.Kline, 0, len(result))
+	for i := range result {
+		kline, err := result[i].ToDomain(exchange, symbol, interval)
+		if err != nil {
+			return nil, fmt.Errorf("binance: kline %d: %w", result[i].OpenTime, err)
+		}
+		klines = append(klines, *kline)
+	}
+
+	return klines, nil
+}
+
+// KlineResponse is a single row returned by /api/v3/klines.
+// Rows are positional arrays:
+//
+//	[openTime, open, high, low, close, volume, closeTime, quoteVolume,
+//	 tradeCount, takerBuyBaseVolume, takerBuyQuoteVolume, ignore]
+//
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#kline-candlestick-data
+type KlineResponse struct {
+	OpenTime            int64
+	Open                string
+	High                string
+	Low                 string
+	Close               string
+	Volume              string
+	CloseTime           int64
+	QuoteVolume         string
+	TradeCount          int64
+	TakerBuyBaseVolume  string
+	TakerBuyQuoteVolume string
+}
+
+// UnmarshalJSON decodes the positional array form.
+func (k *KlineResponse) UnmarshalJSON(data []byte) error {
+	var ignore json.RawMessage
+	row := []any{
+		&k.OpenTime, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume,
+		&k.CloseTime, &k.QuoteVolume, &k.TradeCount,
+		&k.TakerBuyBaseVolume, &k.TakerBuyQuoteVolume, &ignore,
+	}
+	return json.Unmarshal(data, &row)
+}
+
+// ToDomain converts KlineResponse to domain.Kline.
+// REST klines carry no symbol or interval, so both are taken from the request.
+// The latest kline of a range may still be open; IsClosed reports whether its
+// close time has passed.
+func (k *KlineResponse) ToDomain(exchange, symbol, interval string) (*domain.Kline, error) {
+	open, err := domain.NewDecimal(k.Open)
+	if err != nil {
+		return nil, fmt.Errorf("parse open: %w", err)
+	}
+
+	high, err := domain.NewDecimal(k.High)
+	if err != nil {
+		return nil, fmt.Errorf("parse high: %w", err)
+	}
+
+	low, err := domain.NewDecimal(k.Low)
+	if err != nil {
+		return nil, fmt.Errorf("parse low: %w", err)
+	}
+
+	close, err := domain.NewDecimal(k.Close)
+	if err != nil {
+		return nil, fmt.Errorf("parse close: %w", err)
+	}
+
+	volume, err := domain.NewDecimal(k.Volume)
+	if err != nil {
+		return nil, fmt.Errorf("parse volume: %w", err)
+	}
+
+	quoteVolume, err := domain.NewDecimal(k.QuoteVolume)
+	if err != nil {
+		return nil, fmt.Errorf("parse quote_volume: %w", err)
+	}
+
+	takerBuyVolume, err := domain.NewDecimal(k.TakerBuyBaseVolume)
+	if err != nil {
+		return nil, fmt.Errorf("parse taker_buy_volume: %w", err)
+	}
+
+	takerBuyQuoteVolume, err := domain.NewDecimal(k.TakerBuyQuoteVolume)
+	if err != nil {
+		return nil, fmt.Errorf("parse taker_buy_quote_volume: %w", err)
+	}
+
+	closeTime := time.UnixMilli(k.CloseTime)
+
+	return &domain.Kline{
+		Exchange:            exchange,
+		Symbol:              symbols.Normalize(symbol),
+		Interval:            interval,
+		OpenTime:            time.UnixMilli(k.OpenTime),
+		CloseTime:           closeTime,
+		Open:                open,
+		High:                high,
+		Low:                 low,
+		Close:               close,
+		Volume:              volume,
+		QuoteVolume:         quoteVolume,
+		TradeCount:          k.TradeCount,
+		TakerBuyVolume:      takerBuyVolume,
+		TakerBuyQuoteVolume: takerBuyQuoteVolume,
+		IsClosed:            time.Now().After(closeTime),
+	}, nil
+}
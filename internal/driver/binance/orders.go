@@ -0,0 +1,575 @@
+package binance
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/util"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+	"resty.dev/v3"
+)
+
+// Order response types for newOrderRespType.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#new-order-trade
+const (
+	OrderRespACK    = "ACK"    // orderId, clientOrderId and transactTime only
+	OrderRespRESULT = "RESULT" // ACK plus order state
+	OrderRespFULL   = "FULL"   // RESULT plus fills
+)
+
+// maxAllOrdersLimit is the maximum limit accepted by /api/v3/allOrders.
+const maxAllOrdersLimit = 1000
+
+// maxMyTradesLimit is the maximum limit accepted by /api/v3/myTrades.
+const maxMyTradesLimit = 1000
+
+// defaultMyTradesLimit is the limit /api/v3/myTrades applies when none is sent.
+const defaultMyTradesLimit = 500
+
+// myTradesWindow is the longest startTime to endTime span of /api/v3/myTrades.
+// With only startTime, the exchange returns the 24 hours from it.
+const myTradesWindow = 24 * time.Hour
+
+// PlaceOrder places a new order.
+// API: POST /api/v3/order (HMAC SHA256)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#new-order-trade
+// Weight: 1
+//
+// The response detail depends on Config.OrderResponseType (default: FULL).
+// With ACK, fields not returned by the exchange are filled from the request.
+func (rc *RESTClient) PlaceOrder(ctx context.Context, req *domain.OrderRequest) (*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("binance: API credentials required for PlaceOrder")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := rc.newOrderParams(req)
+
+	var result OrderResponse
+
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetQueryParams(params).
+			SetResult(&result).
+			Post(ENewOrder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.placedOrder(req)
+}
+
+// newOrderParams builds the new order parameters shared by REST and the WebSocket API.
+func (rc *RESTClient) newOrderParams(req *domain.OrderRequest) map[string]string {
+	respType := rc.config.OrderResponseType
+	if respType == "" {
+		respType = OrderRespFULL
+	}
+
+	params := map[string]string{
+		"symbol":           symbols.ExchangeSymbol(req.Symbol),
+		"side":             string(req.Side),
+		"type":             string(req.Type),
+		"newOrderRespType": respType,
+	}
+	if req.Quantity != nil && !domain.IsZero(req.Quantity) {
+		params["quantity"] = req.Quantity.Text('f')
+	} else if req.QuoteQuantity != nil {
+		params["quoteOrderQty"] = req.QuoteQuantity.Text('f')
+	}
+	if req.Type == domain.OrderTypeLimit {
+		params["price"] = req.Price.Text('f')
+		params["timeInForce"] = "GTC"
+	}
+	if req.TimeInForce != "" {
+		params["timeInForce"] = req.TimeInForce
+	}
+	if req.ClientOrderID != "" {
+		params["newClientOrderId"] = req.ClientOrderID
+	}
+	if req.StopPrice != nil && !domain.IsZero(req.StopPrice) {
+		params["stopPrice"] = req.StopPrice.Text('f')
+	}
+	if req.IcebergQuantity != nil && !domain.IsZero(req.IcebergQuantity) {
+		params["icebergQty"] = req.IcebergQuantity.Text('f')
+	}
+
+	return params
+}
+
+// placedOrder converts a new order response to domain.Order.
+// ACK responses carry no order state, so it is filled from the request.
+func (o *OrderResponse) placedOrder(req *domain.OrderRequest) (*domain.Order, error) {
+	order, err := o.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Status == "" {
+		order.Side = req.Side
+		order.Type = req.Type
+		order.Price = util.OrZero(req.Price)
+		order.Quantity = util.OrZero(req.Quantity)
+	}
+
+	return order, nil
+}
+
+// CancelOrder cancels an active order by order ID or client order ID.
+// API: DELETE /api/v3/order (HMAC SHA256)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#cancel-order-trade
+// Weight: 1
+func (rc *RESTClient) CancelOrder(ctx context.Context, req *domain.CancelRequest) (*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("binance: API credentials required for CancelOrder")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := cancelOrderParams(req)
+
+	var result OrderResponse
+
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetQueryParams(params).
+			SetResult(&result).
+			Delete(ECancelOrder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.ToDomain()
+}
+
+// cancelOrderParams builds the cancel parameters shared by REST and the WebSocket API.
+func cancelOrderParams(req *domain.CancelRequest) map[string]string {
+	params := map[string]string{
+		"symbol": symbols.ExchangeSymbol(req.Symbol),
+	}
+	if req.OrderID != "" {
+		params["orderId"] = req.OrderID
+	}
+	if req.ClientOrderID != "" {
+		params["origClientOrderId"] = req.ClientOrderID
+	}
+	return params
+}
+
+// CancelAllOrders cancels all active orders on a symbol, including OCO legs.
+// API: DELETE /api/v3/openOrders (HMAC SHA256)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#cancel-all-open-orders-on-a-symbol-trade
+// Weight: 1
+//
+// OCO list summaries in the response are skipped; their legs are reported individually.
+// A symbol without open orders (-2011) returns no orders and no error.
+func (rc *RESTClient) CancelAllOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("binance: API credentials required for CancelAllOrders")
+	}
+
+	var result []OrderResponse
+
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetQueryParam("symbol", symbols.ExchangeSymbol(symbol)).
+			SetResult(&result).
+			Delete(ECancelAllOpenOrders)
+	})
+	var notFound *errors.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ordersToDomain(result)
+}
+
+// GetOrder returns an order by exchange order ID.
+// API: GET /api/v3/order (HMAC SHA256)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#query-order-user_data
+// Weight: 4
+func (rc *RESTClient) GetOrder(ctx context.Context, symbol, orderID string) (*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("binance: API credentials required for GetOrder")
+	}
+
+	var result OrderResponse
+
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetQueryParams(map[string]string{
+				"symbol":  symbols.ExchangeSymbol(symbol),
+				"orderId": orderID,
+			}).
+			SetResult(&result).
+			Get(EQueryOrder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.ToDomain()
+}
+
+// GetOpenOrders returns all open orders, optionally filtered by symbol.
+// API: GET /api/v3/openOrders (HMAC SHA256)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#current-open-orders-user_data
+// Weight: 6 for a single symbol, 80 when the symbol is omitted
+func (rc *RESTClient) GetOpenOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("binance: API credentials required for GetOpenOrders")
+	}
+
+	params := map[string]string{}
+	if symbol != "" {
+		params["symbol"] = symbols.ExchangeSymbol(symbol)
+	}
+
+	var result []OrderResponse
+
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetQueryParams(params).
+			SetResult(&result).
+			Get(EOpenOrders)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordersToDomain(result)
+}
+
+// GetAllOrders returns all orders for a symbol: active, canceled or filled.
+// API: GET /api/v3/allOrders (HMAC SHA256)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#all-orders-user_data
+// Weight: 20
+//
+// Zero startTime/endTime are omitted. limit defaults to 500 and is capped at 1000.
+// The time window between startTime and endTime can't exceed 24 hours.
+func (rc *RESTClient) GetAllOrders(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("binance: API credentials required for GetAllOrders")
+	}
+
+	params := map[string]string{
+		"symbol": symbols.ExchangeSymbol(symbol),
+	}
+	if !startTime.IsZero() {
+		params["startTime"] = strconv.FormatInt(startTime.UnixMilli(), 10)
+	}
+	if !endTime.IsZero() {
+		params["endTime"] = strconv.FormatInt(endTime.UnixMilli(), 10)
+	}
+	if limit > 0 {
+		params["limit"] = strconv.Itoa(min(limit, maxAllOrdersLimit))
+	}
+
+	var result []OrderResponse
+
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetQueryParams(params).
+			SetResult(&result).
+			Get(EAllOrders)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordersToDomain(result)
+}
+
+// GetMyTrades returns the account's trades for a symbol, oldest first.
+// API: GET /api/v3/myTrades (HMAC SHA256)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#account-trade-list-user_data
+// Weight: 20
+//
+// A zero since is omitted (the exchange returns the most recent trades).
+// Otherwise 24-hour windows from since are queried in turn until limit trades
+// are found or a window reaches now, one request (weight 20) per window.
+// limit defaults to 500 and is capped at 1000.
+func (rc *RESTClient) GetMyTrades(ctx context.Context, symbol string, since time.Time, limit int) ([]domain.Trade, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("binance: API credentials required for GetMyTrades")
+	}
+
+	limit = min(cmp.Or(max(limit, 0), defaultMyTradesLimit), maxMyTradesLimit)
+	params := map[string]string{
+		"symbol": symbols.ExchangeSymbol(symbol),
+		"limit":  strconv.Itoa(limit),
+	}
+	if since.IsZero() {
+		return rc.myTrades(ctx, params)
+	}
+
+	var trades []domain.Trade
+	now := time.Now()
+	for start := since; ; {
+		end := start.Add(myTradesWindow)
+		params["startTime"] = strconv.FormatInt(start.UnixMilli(), 10)
+		params["endTime"] = strconv.FormatInt(end.UnixMilli()-1, 10)
+		params["limit"] = strconv.Itoa(limit - len(trades))
+
+		page, err := rc.myTrades(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, page...)
+
+		if len(trades) >= limit || !end.Before(now) {
+			return trades, nil
+		}
+		start = end
+	}
+}
+
+// myTrades sends one /api/v3/myTrades request.
+func (rc *RESTClient) myTrades(ctx context.Context, params map[string]string) ([]domain.Trade, error) {
+	var result []AccountTrade
+
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetQueryParams(params).
+			SetResult(&result).
+			Get(EMyTrades)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]domain.Trade, 0, len(result))
+	for i := range result {
+		trade, err := result[i].ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, *trade)
+	}
+	return trades, nil
+}
+
+// AccountTrade represents a trade of the account returned by /api/v3/myTrades.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#account-trade-list-user_data
+type AccountTrade struct {
+	Symbol          string `json:"symbol"`
+	ID              int64  `json:"id"`
+	OrderID         int64  `json:"orderId"`
+	OrderListID     int64  `json:"orderListId"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+	IsBestMatch     bool   `json:"isBestMatch"`
+}
+
+// ToDomain converts AccountTrade to domain.Trade.
+func (t *AccountTrade) ToDomain() (*domain.Trade, error) {
+	price, err := domain.NewDecimal(t.Price)
+	if err != nil {
+		return nil, fmt.Errorf("parse trade price: %w", err)
+	}
+	qty, err := domain.NewDecimal(t.Qty)
+	if err != nil {
+		return nil, fmt.Errorf("parse trade qty: %w", err)
+	}
+
+	side := domain.OrderSideSell
+	if t.IsBuyer {
+		side = domain.OrderSideBuy
+	}
+
+	return &domain.Trade{
+		Exchange:        exchange,
+		Symbol:          symbols.Normalize(t.Symbol),
+		ID:              strconv.FormatInt(t.ID, 10),
+		OrderID:         strconv.FormatInt(t.OrderID, 10),
+		Price:           price,
+		Quantity:        qty,
+		QuoteQuantity:   util.ParseOrZero(t.QuoteQty),
+		Commission:      util.ParseOrZero(t.Commission),
+		CommissionAsset: t.CommissionAsset,
+		Side:            side,
+		IsMaker:         t.IsMaker,
+		Timestamp:       time.UnixMilli(t.Time),
+	}, nil
+}
+
+// OrderResponse represents an order returned by the order endpoints.
+// It covers the ACK, RESULT and FULL new order responses as well as
+// cancel and query responses.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#new-order-trade
+type OrderResponse struct {
+	Symbol                  string      `json:"symbol"`
+	OrderID                 int64       `json:"orderId"`
+	OrderListID             int64       `json:"orderListId"`
+	ClientOrderID           string      `json:"clientOrderId"`
+	OrigClientOrderID       string      `json:"origClientOrderId"` // Cancel only
+	TransactTime            int64       `json:"transactTime"`      // New order and cancel
+	Price                   string      `json:"price"`
+	OrigQty                 string      `json:"origQty"`
+	ExecutedQty             string      `json:"executedQty"`
+	CummulativeQuoteQty     string      `json:"cummulativeQuoteQty"`
+	OrigQuoteOrderQty       string      `json:"origQuoteOrderQty"`
+	Status                  string      `json:"status"`
+	TimeInForce             string      `json:"timeInForce"`
+	Type                    string      `json:"type"`
+	Side                    string      `json:"side"`
+	StopPrice               string      `json:"stopPrice"`
+	IcebergQty              string      `json:"icebergQty"`
+	Time                    int64       `json:"time"`       // Query only
+	UpdateTime              int64       `json:"updateTime"` // Query only
+	IsWorking               *bool       `json:"isWorking"`  // Query only
+	WorkingTime             int64       `json:"workingTime"`
+	SelfTradePreventionMode string      `json:"selfTradePreventionMode"`
+	Fills                   []OrderFill `json:"fills"` // FULL only
+}
+
+// OrderFill represents a single fill in a FULL new order response.
+type OrderFill struct {
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeID         int64  `json:"tradeId"`
+}
+
+// ToDomain converts OrderResponse to domain.Order.
+// ACK responses have no status and are reported as NEW.
+func (o *OrderResponse) ToDomain() (*domain.Order, error) {
+	var side domain.OrderSide
+	if o.Side != "" {
+		var err error
+		if side, err = parseOrderSide(o.Side); err != nil {
+			return nil, err
+		}
+	}
+
+	status := domain.OrderStatusNew
+	if o.Status != "" {
+		status = parseOrderStatus(o.Status)
+	}
+
+	clientOrderID := o.ClientOrderID
+	if o.OrigClientOrderID != "" {
+		clientOrderID = o.OrigClientOrderID
+	}
+
+	createdAt := time.UnixMilli(o.TransactTime)
+	if o.Time > 0 {
+		createdAt = time.UnixMilli(o.Time)
+	}
+	updatedAt := createdAt
+	if o.UpdateTime > 0 {
+		updatedAt = time.UnixMilli(o.UpdateTime)
+	}
+
+	isWorking := !status.IsFinal()
+	if o.IsWorking != nil {
+		isWorking = *o.IsWorking
+	}
+
+	order := &domain.Order{
+		Exchange:       exchange,
+		Symbol:         symbols.Normalize(o.Symbol),
+		ID:             strconv.FormatInt(o.OrderID, 10),
+		ClientOrderID:  clientOrderID,
+		Side:           side,
+		Type:           parseOrderType(o.Type),
+		Status:         status,
+		Price:          util.ParseOrZero(o.Price),
+		Quantity:       util.ParseOrZero(o.OrigQty),
+		FilledQuantity: util.ParseOrZero(o.ExecutedQty),
+		QuoteQuantity:  util.ParseOrZero(o.CummulativeQuoteQty),
+		Commission:     domain.Zero(),
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		IsWorking:      isWorking,
+	}
+
+	if len(o.Fills) > 0 {
+		order.Fills = make([]domain.Trade, 0, len(o.Fills))
+		for _, f := range o.Fills {
+			fill, err := f.ToDomain(order, createdAt)
+			if err != nil {
+				return nil, err
+			}
+			order.Fills = append(order.Fills, *fill)
+
+			// Commission is only summed while it is paid in a single asset
+			if order.CommissionAsset == "" || order.CommissionAsset == fill.CommissionAsset {
+				order.CommissionAsset = fill.CommissionAsset
+				order.Commission = domain.Add(order.Commission, fill.Commission)
+			}
+		}
+		order.TradeID = order.Fills[len(order.Fills)-1].ID
+	}
+
+	return order, nil
+}
+
+// ToDomain converts OrderFill to a domain.Trade belonging to order.
+func (f *OrderFill) ToDomain(order *domain.Order, ts time.Time) (*domain.Trade, error) {
+	price, err := domain.NewDecimal(f.Price)
+	if err != nil {
+		return nil, fmt.Errorf("parse fill price: %w", err)
+	}
+	qty, err := domain.NewDecimal(f.Qty)
+	if err != nil {
+		return nil, fmt.Errorf("parse fill qty: %w", err)
+	}
+
+	return &domain.Trade{
+		Exchange:        exchange,
+		Symbol:          order.Symbol,
+		ID:              strconv.FormatInt(f.TradeID, 10),
+		OrderID:         order.ID,
+		Price:           price,
+		Quantity:        qty,
+		QuoteQuantity:   domain.Mul(price, qty),
+		Commission:      util.ParseOrZero(f.Commission),
+		CommissionAsset: f.CommissionAsset,
+		Side:            order.Side,
+		Timestamp:       ts,
+	}, nil
+}
+
+// ordersToDomain converts a list of order responses.
+// Entries without an order ID (OCO list summaries) are skipped.
+func ordersToDomain(responses []OrderResponse) ([]*domain.Order, error) {
+	orders := make([]*domain.Order, 0, len(responses))
+	for i := range responses {
+		if responses[i].OrderID == 0 {
+			continue
+		}
+		order, err := responses[i].ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
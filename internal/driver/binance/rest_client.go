@@ -1,18 +1,23 @@
 package binance
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/lilwiggy/ex-act/internal/ratelimit"
+	internalSync "github.com/lilwiggy/ex-act/internal/sync"
 	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
 	"github.com/lilwiggy/ex-act/pkg/errors"
 	"resty.dev/v3"
 )
@@ -22,23 +27,22 @@ import (
 // API Version: v3 (verified 2026-02-16)
 //
 // Features:
-//   - Automatic HMAC-SHA256 signing for authenticated requests
-//   - Weight-based rate limiting with server header tracking
+//   - Automatic HMAC-SHA256, RSA or Ed25519 signing for authenticated requests
+//   - Signed timestamps from a synchronised clock, resynced on -1021
+//   - Multi-bucket rate limiting (weight, orders, raw requests) from exchangeInfo
 //   - Context-based timeouts (not http.Client.Timeout)
-//   - Automatic weight tracking via X-MBX-USED-WEIGHT-* headers
+//   - Usage tracking via X-MBX-USED-WEIGHT-* and X-MBX-ORDER-COUNT-* headers
 //
 // IMPORTANT: resty v3 requires calling Close() when done (breaking change from v2)
 type RESTClient struct {
 	client      *resty.Client
 	baseURL     string
 	signer      *Signer
-	rateLimiter *ratelimit.WeightedLimiter
+	rateLimiter *ratelimit.CompositeLimiter
+	gate        *ratelimit.Gate
+	clock       driver.Clock
 	config      Config
 
-	// Server time offset for clock synchronization
-	timeOffset   time.Duration
-	timeOffsetMu sync.RWMutex
-
 	// Track if client is closed
 	closed   bool
 	closedMu sync.RWMutex
@@ -50,16 +54,26 @@ type Config struct {
 	BaseURL string
 	// APIKey is the Binance API key (required for authenticated requests)
 	APIKey string
-	// APISecret is the Binance API secret (required for authenticated requests)
+	// APISecret is the Binance API secret, or the PKCS#8 PEM private key for
+	// RSA and Ed25519 keys (required for authenticated requests)
 	APISecret string
+	// KeyType is the API key type: driver.KeyTypeHMAC (default), KeyTypeRSA or KeyTypeEd25519
+	KeyType string
 	// Timeout is the request timeout (default: 10 seconds)
 	Timeout time.Duration
-	// MaxWeight is the maximum rate limit weight per minute (default: 1200)
+	// MaxWeight caps the REQUEST_WEIGHT limit per minute (default: the exchangeInfo limit)
 	MaxWeight int
 	// RecvWindow is the recvWindow for signed requests in milliseconds (default: 5000)
 	RecvWindow int64
 	// Testnet enables testnet mode (changes base URL)
 	Testnet bool
+	// OrderResponseType is the newOrderRespType for PlaceOrder: ACK, RESULT or FULL (default: FULL)
+	OrderResponseType string
+	// Gate is the backoff gate shared with the WebSocket clients (default: a new gate)
+	Gate *ratelimit.Gate
+	// Clock is the synchronised clock for signed timestamps (default: a clock
+	// synced from GET /api/v3/time on the first -1021)
+	Clock driver.Clock
 }
 
 // NewRESTClient creates a new Binance REST client with middleware.
@@ -89,25 +103,32 @@ func NewRESTClient(cfg Config) (*RESTClient, error) {
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 10 * time.Second
 	}
-	if cfg.MaxWeight == 0 {
-		cfg.MaxWeight = ratelimit.DefaultMaxWeight
-	}
 	if cfg.RecvWindow == 0 {
 		cfg.RecvWindow = DefaultRecvWindow
 	}
+	if cfg.Gate == nil {
+		cfg.Gate = ratelimit.NewGate()
+	}
+	var ownClock *internalSync.ClockSync
+	if cfg.Clock == nil {
+		ownClock = internalSync.NewClockSync(exchange, internalSync.ClockConfig{})
+		cfg.Clock = ownClock
+	}
 
 	// Create signer if credentials provided
 	var signer *Signer
 	if cfg.APIKey != "" && cfg.APISecret != "" {
-		signer = NewSigner(cfg.APIKey, cfg.APISecret, cfg.RecvWindow)
+		key, err := NewKeySigner(cfg.KeyType, cfg.APISecret)
+		if err != nil {
+			return nil, fmt.Errorf("binance: %w", err)
+		}
+		signer = NewSignerWithKey(cfg.APIKey, key, cfg.RecvWindow)
 		if err := signer.ValidateCredentials(); err != nil {
 			return nil, err
 		}
+		signer.SetClock(cfg.Clock.UnixMilli)
 	}
 
-	// Create rate limiter
-	rateLimiter := ratelimit.NewWeightedLimiter(cfg.MaxWeight)
-
 	// Create resty client
 	client := resty.New()
 	client.SetBaseURL(cfg.BaseURL)
@@ -127,9 +148,14 @@ func NewRESTClient(cfg Config) (*RESTClient, error) {
 		client:      client,
 		baseURL:     cfg.BaseURL,
 		signer:      signer,
-		rateLimiter: rateLimiter,
+		rateLimiter: ratelimit.NewCompositeLimiter(capWeight(ratelimit.DefaultLimits, cfg.MaxWeight)),
+		gate:        cfg.Gate,
+		clock:       cfg.Clock,
 		config:      cfg,
 	}
+	if ownClock != nil {
+		ownClock.SetTimeProvider(rc.GetServerTime)
+	}
 
 	rc.setupMiddleware()
 
@@ -148,13 +174,21 @@ func (rc *RESTClient) setupMiddleware() {
 		}
 		rc.closedMu.RUnlock()
 
-		// Get endpoint weight
+		// Hold back every request during a rate limit backoff or IP ban
+		ctx := req.Context()
+		if err := rc.gate.Enter(ctx); err != nil {
+			return err
+		}
+
+		// Get request cost
 		endpoint := req.URL
-		weight := getEndpointWeight(endpoint)
+		cost := ratelimit.Cost{
+			Weight: EndpointWeight(req.Method, endpointPath(endpoint), req.QueryParams),
+			Orders: getOrderCount(req.Method, endpoint),
+		}
 
-		// Wait for rate limit (blocking)
-		ctx := req.Context()
-		if err := rc.rateLimiter.Wait(ctx, weight); err != nil {
+		// Wait for every rate limit bucket (blocking)
+		if err := rc.rateLimiter.Wait(ctx, cost); err != nil {
 			return fmt.Errorf("binance: rate limit wait failed: %w", err)
 		}
 
@@ -172,31 +206,124 @@ func (rc *RESTClient) setupMiddleware() {
 		return nil
 	})
 
-	// AddResponseMiddleware: Weight tracking and error handling
+	// AddResponseMiddleware: Usage tracking and error handling
 	rc.client.AddResponseMiddleware(func(c *resty.Client, resp *resty.Response) error {
-		// Track weight from response headers
-		rc.trackWeightFromHeaders(resp.Header())
+		// Reconcile local usage with the server's counts
+		rc.trackUsageFromHeaders(resp.Header())
 		return nil
 	})
 }
 
-// trackWeightFromHeaders extracts and tracks weight from X-MBX-USED-WEIGHT-* headers.
-func (rc *RESTClient) trackWeightFromHeaders(header http.Header) {
-	// X-MBX-USED-WEIGHT-1M for 1-minute weight
-	weightStr := header.Get("X-MBX-USED-WEIGHT-1m")
-	if weightStr == "" {
-		weightStr = header.Get("X-MBX-USED-WEIGHT-1M")
+// trackUsageFromHeaders reconciles the limiter with the server's usage headers.
+// Headers carry the bucket interval as a suffix:
+//
+//	X-MBX-USED-WEIGHT-1M  -> REQUEST_WEIGHT per minute
+//	X-MBX-ORDER-COUNT-10S -> ORDERS per 10 seconds
+//	X-MBX-ORDER-COUNT-1D  -> ORDERS per day
+func (rc *RESTClient) trackUsageFromHeaders(header http.Header) {
+	for key, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+
+		var limitType, suffix string
+		name := strings.ToUpper(key)
+		switch {
+		case strings.HasPrefix(name, HeaderUsedWeight):
+			limitType, suffix = ratelimit.LimitRequestWeight, name[len(HeaderUsedWeight):]
+		case strings.HasPrefix(name, HeaderOrderCount):
+			limitType, suffix = ratelimit.LimitOrders, name[len(HeaderOrderCount):]
+		default:
+			continue
+		}
+
+		interval, ok := parseHeaderInterval(suffix)
+		if !ok {
+			continue
+		}
+		if used, err := strconv.Atoi(values[0]); err == nil {
+			rc.rateLimiter.Reconcile(limitType, interval, used)
+		}
+	}
+}
+
+// parseHeaderInterval parses a usage header interval suffix (e.g., "1M", "10S", "1D").
+func parseHeaderInterval(suffix string) (time.Duration, bool) {
+	if len(suffix) < 2 {
+		return 0, false
+	}
+	num, err := strconv.Atoi(suffix[:len(suffix)-1])
+	if err != nil || num <= 0 {
+		return 0, false
+	}
+
+	unit, ok := intervalUnits[suffix[len(suffix)-1:]]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(num) * unit, true
+}
+
+// intervalUnits maps usage header interval letters to durations.
+var intervalUnits = map[string]time.Duration{
+	"S": time.Second,
+	"M": time.Minute,
+	"H": time.Hour,
+	"D": 24 * time.Hour,
+}
+
+// configureRateLimits applies the exchangeInfo rate limits to the limiter.
+// Entries with unknown intervals are skipped; defaults are kept if none are usable.
+func (rc *RESTClient) configureRateLimits(rateLimits []RateLimit) {
+	limits := make([]ratelimit.Limit, 0, len(rateLimits))
+	for _, rl := range rateLimits {
+		if limit, ok := rl.ToLimit(); ok {
+			limits = append(limits, limit)
+		}
+	}
+	if len(limits) == 0 {
+		return
 	}
-	if weightStr != "" {
-		if weight, err := strconv.Atoi(weightStr); err == nil {
-			rc.rateLimiter.UpdateWeight(weight)
+	rc.rateLimiter.Configure(capWeight(limits, rc.config.MaxWeight))
+}
+
+// capWeight lowers REQUEST_WEIGHT limits to maxWeight per minute.
+// maxWeight <= 0 leaves limits unchanged.
+func capWeight(limits []ratelimit.Limit, maxWeight int) []ratelimit.Limit {
+	capped := make([]ratelimit.Limit, len(limits))
+	copy(capped, limits)
+	if maxWeight <= 0 {
+		return capped
+	}
+	for i, limit := range capped {
+		if limit.Type == ratelimit.LimitRequestWeight && limit.Interval == time.Minute {
+			capped[i].Limit = min(limit.Limit, maxWeight)
 		}
 	}
+	return capped
 }
 
-// getEndpointWeight returns the weight for an endpoint.
+// Gate returns the backoff gate that holds back requests after a 429 or 418.
+func (rc *RESTClient) Gate() *ratelimit.Gate {
+	return rc.gate
+}
+
+// RateLimitStats returns the state of every rate limit bucket.
+func (rc *RESTClient) RateLimitStats() []ratelimit.BucketStats {
+	return rc.rateLimiter.Stats()
+}
+
+// getOrderCount returns the ORDERS units consumed by a request.
+func getOrderCount(method, endpoint string) int {
+	if method != http.MethodPost {
+		return 0
+	}
+	return OrderCountEndpoints[endpointPath(endpoint)]
+}
+
+// endpointPath strips the base URL and query from an endpoint.
 // Handles both full URLs and path-only endpoints.
-func getEndpointWeight(endpoint string) int {
+func endpointPath(endpoint string) string {
 	// Extract path from full URL if needed
 	if strings.HasPrefix(endpoint, "http") {
 		// Find the path part after the base URL
@@ -205,12 +332,20 @@ func getEndpointWeight(endpoint string) int {
 			endpoint = endpoint[idx:]
 		}
 	}
-	return GetEndpointWeight(endpoint)
+	if idx := strings.Index(endpoint, "?"); idx != -1 {
+		endpoint = endpoint[:idx]
+	}
+	return endpoint
 }
 
 // needsSigning determines if an endpoint requires authentication.
 // Most /api/v3/* endpoints are public, but user data and trading require signing.
+// User data stream endpoints only need the X-MBX-APIKEY header.
 func needsSigning(endpoint string) bool {
+	if strings.Contains(endpoint, EUserDataStream) {
+		return false
+	}
+
 	// Public endpoints that don't need signing
 	publicEndpoints := []string{
 		EPing,
@@ -218,6 +353,7 @@ func needsSigning(endpoint string) bool {
 		EExchangeInfo,
 		EDepth,
 		ETrades,
+		EKlines,
 		ETicker,
 		ETickerPrice,
 		ETickerBook,
@@ -279,14 +415,32 @@ func (rc *RESTClient) GetServerTime(ctx context.Context) (int64, error) {
 }
 
 // GetExchangeInfo returns exchange information including rate limits and symbol info.
+// The query selects symbols with the symbols or permissions parameter; both
+// cannot be sent together, so permissions are then applied to the response.
+// Rate limits are applied to the limiter from full responses only.
 // API: GET /api/v3/exchangeInfo
 // Documentation: https://binance-docs.github.io/apidocs/spot/en/#exchange-information
 // Weight: 20
-func (rc *RESTClient) GetExchangeInfo(ctx context.Context) (*ExchangeInfo, error) {
+func (rc *RESTClient) GetExchangeInfo(ctx context.Context, query driver.ExchangeInfoQuery) (*ExchangeInfo, error) {
 	var result ExchangeInfo
 
+	params := make(map[string]string)
+	switch {
+	case len(query.Symbols) == 1:
+		params["symbol"] = symbols.ExchangeSymbol(query.Symbols[0])
+	case len(query.Symbols) > 1:
+		names := make([]string, len(query.Symbols))
+		for i, symbol := range query.Symbols {
+			names[i] = symbols.ExchangeSymbol(symbol)
+		}
+		params["symbols"] = jsonArray(names)
+	case len(query.Permissions) > 0:
+		params["permissions"] = jsonArray(query.Permissions)
+	}
+
 	resp, err := rc.client.R().
 		SetContext(ctx).
+		SetQueryParams(params).
 		SetResult(&result).
 		Get(EExchangeInfo)
 	if err != nil {
@@ -297,36 +451,59 @@ func (rc *RESTClient) GetExchangeInfo(ctx context.Context) (*ExchangeInfo, error
 		return nil, rc.handleErrorResponse(resp)
 	}
 
+	// Configure replaces every bucket, so only the full listing is trusted
+	// to carry the complete set
+	if query.IsZero() {
+		rc.configureRateLimits(result.RateLimits)
+	}
+
+	if len(query.Symbols) > 0 && len(query.Permissions) > 0 {
+		result.Symbols = slices.DeleteFunc(result.Symbols, func(s SymbolInfo) bool {
+			return !slices.ContainsFunc(query.Permissions, s.hasPermission)
+		})
+	}
+
 	return &result, nil
 }
 
-// GetServerTimeOffset returns the time offset between local and server time.
-// This is useful for ensuring requests don't fail due to clock skew.
-// Call this after getting server time.
-func (rc *RESTClient) GetServerTimeOffset() time.Duration {
-	rc.timeOffsetMu.RLock()
-	defer rc.timeOffsetMu.RUnlock()
-	return rc.timeOffset
+// jsonArray encodes strings as a JSON array query parameter value.
+func jsonArray(values []string) string {
+	b, _ := json.Marshal(values)
+	return string(b)
 }
 
-// SyncTime synchronizes local time with server time.
-// This should be called periodically to prevent timestamp-related errors.
-func (rc *RESTClient) SyncTime(ctx context.Context) error {
-	localBefore := time.Now().UnixMilli()
-	serverTime, err := rc.GetServerTime(ctx)
-	if err != nil {
-		return err
-	}
-	localAfter := time.Now().UnixMilli()
+// Clock returns the clock used for signed request timestamps.
+func (rc *RESTClient) Clock() driver.Clock {
+	return rc.clock
+}
 
-	// Calculate offset using midpoint of local times
-	localMid := (localBefore + localAfter) / 2
-	offset := time.Duration(serverTime-localMid) * time.Millisecond
+// doSigned sends a signed request built by send and converts error responses.
+// If the server rejects the timestamp (-1021, outside recvWindow), the clock
+// is resynced and the request is rebuilt and sent once more with a fresh
+// timestamp and signature.
+func (rc *RESTClient) doSigned(send func() (*resty.Response, error)) error {
+	err := rc.sendChecked(send)
 
-	rc.timeOffsetMu.Lock()
-	rc.timeOffset = offset
-	rc.timeOffsetMu.Unlock()
+	var clockErr *errors.ClockSyncError
+	if !errors.As(err, &clockErr) {
+		return err
+	}
+	// A ClockSyncError from Sync still applies the measured offset
+	if syncErr := rc.clock.Sync(); syncErr != nil && !errors.As(syncErr, &clockErr) {
+		return err
+	}
+	return rc.sendChecked(send)
+}
 
+// sendChecked sends a request and converts an error response to a typed error.
+func (rc *RESTClient) sendChecked(send func() (*resty.Response, error)) error {
+	resp, err := send()
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccess() {
+		return rc.handleErrorResponse(resp)
+	}
 	return nil
 }
 
@@ -341,25 +518,105 @@ func (rc *RESTClient) handleErrorResponse(resp *resty.Response) error {
 	}
 	body := string(bodyBytes)
 
+	retryAfter := parseRetryAfter(resp.Header())
+
 	// Parse Binance error format
 	var binanceErr struct {
 		Code int    `json:"code"`
 		Msg  string `json:"msg"`
 	}
-	if err := json.Unmarshal(bodyBytes, &binanceErr); err == nil && binanceErr.Msg != "" {
-		return rc.createBinanceError(statusCode, binanceErr.Code, binanceErr.Msg)
+	var err error
+	switch {
+	case json.Unmarshal(bodyBytes, &binanceErr) == nil && binanceErr.Msg != "":
+		err = rc.createBinanceError(statusCode, binanceErr.Code, binanceErr.Msg, retryAfter)
+	case statusCode == http.StatusTeapot || statusCode == http.StatusTooManyRequests:
+		err = rc.createBinanceError(statusCode, 0, body, retryAfter)
+	default:
+		// Generic HTTP error
+		return errors.NewConnectionError("binance", resp.Request.URL, fmt.Sprintf("HTTP %d: %s", statusCode, body), false)
+	}
+
+	// IP-level limits (418, or 429 with Retry-After) close the gate for every caller.
+	// Order count 429s (-1015) carry no Retry-After and only affect new orders.
+	if statusCode == http.StatusTeapot || (statusCode == http.StatusTooManyRequests && retryAfter > 0) {
+		rc.backoff(err)
+	}
+	return err
+}
+
+// backoff closes the gate until a rate limit or ban error expires.
+func (rc *RESTClient) backoff(err error) {
+	var banErr *errors.IPBanError
+	if errors.As(err, &banErr) {
+		rc.gate.Close(banErr.Until, err)
+		return
+	}
+	var rateErr *errors.RateLimitError
+	if errors.As(err, &rateErr) {
+		rc.gate.Close(time.Now().Add(rateErr.RetryAfter), err)
 	}
+}
+
+// parseRetryAfter reads the Retry-After header in seconds or HTTP-date form.
+// Returns 0 if absent or invalid.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
 
-	// Generic HTTP error
-	return errors.NewConnectionError("binance", resp.Request.URL, fmt.Sprintf("HTTP %d: %s", statusCode, body), false)
+// banUntilPattern matches the ban expiry in 418 messages, e.g.
+// "Way too many requests; IP(1.2.3.4) banned until 1573470353000. Please use the websocket..."
+var banUntilPattern = regexp.MustCompile(`banned until (\d+)`)
+
+// parseBanUntil extracts the ban expiry from a 418 message.
+// Returns the zero time if the message has none.
+func parseBanUntil(msg string) time.Time {
+	match := banUntilPattern.FindStringSubmatch(msg)
+	if match == nil {
+		return time.Time{}
+	}
+	ms, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
 }
 
 // createBinanceError creates an appropriate error type based on Binance error codes.
-func (rc *RESTClient) createBinanceError(httpStatus, code int, msg string) error {
+// retryAfter is the Retry-After header value (0 if absent).
+func (rc *RESTClient) createBinanceError(httpStatus, code int, msg string, retryAfter time.Duration) error {
+	// IP ban: the message carries the ban expiry
+	if httpStatus == http.StatusTeapot {
+		until := parseBanUntil(msg)
+		if until.IsZero() {
+			until = time.Now().Add(cmp.Or(retryAfter, defaultBanBackoff))
+		}
+		err := errors.NewIPBanError(exchange, msg, time.Until(until))
+		err.Until = until
+		return err
+	}
+
 	// Rate limit errors
-	if code == -1015 || code == -1016 || httpStatus == http.StatusTooManyRequests {
-		retryAfter := 1 * time.Second // Default
-		return errors.NewRateLimitError("binance", retryAfter, 1)
+	if code == -1003 || code == -1015 || code == -1016 || httpStatus == http.StatusTooManyRequests {
+		err := errors.NewRateLimitError(exchange, cmp.Or(retryAfter, defaultRetryAfter), 1)
+		err.Message = msg
+		return err
+	}
+
+	// Timestamp outside recvWindow: the local clock has drifted from the server
+	if code == -1021 {
+		err := errors.NewClockSyncError(exchange, time.Now(), time.UnixMilli(rc.clock.UnixMilli()), 0)
+		err.Message = msg
+		return err
 	}
 
 	// Authentication errors
@@ -367,6 +624,20 @@ func (rc *RESTClient) createBinanceError(httpStatus, code int, msg string) error
 		return fmt.Errorf("binance: authentication failed: %s", msg)
 	}
 
+	// Cancel of an order that is not open (also cancel-all with no open orders)
+	if code == -2011 {
+		err := errors.NewNotFoundError("order", "")
+		err.Message = msg
+		return err
+	}
+
+	// listenKey expired or closed
+	if code == -1125 {
+		err := errors.NewNotFoundError("listen_key", "")
+		err.Message = msg
+		return err
+	}
+
 	// Invalid request
 	if code == -1100 || code == -1101 || code == -1102 || code == -1103 {
 		return errors.NewValidationError("request", nil, msg)
@@ -387,6 +658,7 @@ type ExchangeInfo struct {
 }
 
 // RateLimit represents a rate limit from exchange info.
+// Example: {"rateLimitType":"ORDERS","interval":"SECOND","intervalNum":10,"limit":50}
 type RateLimit struct {
 	RateLimitType string `json:"rateLimitType"`
 	Interval      string `json:"interval"`
@@ -394,25 +666,211 @@ type RateLimit struct {
 	Limit         int    `json:"limit"`
 }
 
+// rateLimitIntervals maps exchangeInfo interval names to durations.
+var rateLimitIntervals = map[string]time.Duration{
+	"SECOND": time.Second,
+	"MINUTE": time.Minute,
+	"HOUR":   time.Hour,
+	"DAY":    24 * time.Hour,
+}
+
+// ToLimit converts RateLimit to a limiter bucket.
+// Returns false for unknown intervals or non-positive values.
+func (r RateLimit) ToLimit() (ratelimit.Limit, bool) {
+	unit, ok := rateLimitIntervals[r.Interval]
+	if !ok || r.IntervalNum <= 0 || r.Limit <= 0 {
+		return ratelimit.Limit{}, false
+	}
+	return ratelimit.Limit{
+		Type:     r.RateLimitType,
+		Interval: time.Duration(r.IntervalNum) * unit,
+		Limit:    r.Limit,
+	}, true
+}
+
 // SymbolInfo represents symbol information.
 type SymbolInfo struct {
-	Symbol                   string           `json:"symbol"`
-	Status                   string           `json:"status"`
-	BaseAsset                string           `json:"baseAsset"`
-	BaseAssetPrecision       int              `json:"baseAssetPrecision"`
-	QuoteAsset               string           `json:"quoteAsset"`
-	QuotePrecision           int              `json:"quotePrecision"`
-	QuoteAssetPrecision      int              `json:"quoteAssetPrecision"`
-	BaseCommissionPrecision  int              `json:"baseCommissionPrecision"`
-	QuoteCommissionPrecision int              `json:"quoteCommissionPrecision"`
-	OrderTypes               []string         `json:"orderTypes"`
-	IcebergAllowed           bool             `json:"icebergAllowed"`
-	OcoAllowed               bool             `json:"ocoAllowed"`
-	OtoAllowed               bool             `json:"otoAllowed"`
-	SpotTradingAllowed       bool             `json:"spotTradingAllowed"`
-	MarginTradingAllowed     bool             `json:"marginTradingAllowed"`
-	Filters                  []map[string]any `json:"filters"`
-	Permissions              []string         `json:"permissions"`
+	Symbol                   string         `json:"symbol"`
+	Status                   string         `json:"status"`
+	BaseAsset                string         `json:"baseAsset"`
+	BaseAssetPrecision       int            `json:"baseAssetPrecision"`
+	QuoteAsset               string         `json:"quoteAsset"`
+	QuotePrecision           int            `json:"quotePrecision"`
+	QuoteAssetPrecision      int            `json:"quoteAssetPrecision"`
+	BaseCommissionPrecision  int            `json:"baseCommissionPrecision"`
+	QuoteCommissionPrecision int            `json:"quoteCommissionPrecision"`
+	OrderTypes               []string       `json:"orderTypes"`
+	IcebergAllowed           bool           `json:"icebergAllowed"`
+	OcoAllowed               bool           `json:"ocoAllowed"`
+	OtoAllowed               bool           `json:"otoAllowed"`
+	SpotTradingAllowed       bool           `json:"spotTradingAllowed"`
+	MarginTradingAllowed     bool           `json:"marginTradingAllowed"`
+	Filters                  []SymbolFilter `json:"filters"`
+	Permissions              []string       `json:"permissions"`    // Deprecated by Binance; see PermissionSets
+	PermissionSets           [][]string     `json:"permissionSets"` // Any one set suffices
+}
+
+// ToDomain converts ExchangeInfo to domain.ExchangeInfo.
+func (e *ExchangeInfo) ToDomain() *domain.ExchangeInfo {
+	info := &domain.ExchangeInfo{
+		Exchange:   exchange,
+		ServerTime: time.UnixMilli(e.ServerTime),
+		Symbols:    make([]domain.SymbolInfo, 0, len(e.Symbols)),
+	}
+	for i := range e.Symbols {
+		info.Symbols = append(info.Symbols, e.Symbols[i].ToDomain())
+	}
+	return info
+}
+
+// ToDomain converts SymbolInfo to domain.SymbolInfo.
+// Limits are taken from PRICE_FILTER, LOT_SIZE and NOTIONAL/MIN_NOTIONAL.
+func (s *SymbolInfo) ToDomain() domain.SymbolInfo {
+	info := domain.SymbolInfo{
+		Exchange:            exchange,
+		Symbol:              domain.FormatSymbol(s.BaseAsset, s.QuoteAsset),
+		BaseAsset:           s.BaseAsset,
+		QuoteAsset:          s.QuoteAsset,
+		ExchangeSymbol:      s.Symbol,
+		Status:              s.Status,
+		BaseAssetPrecision:  s.BaseAssetPrecision,
+		QuoteAssetPrecision: s.QuoteAssetPrecision,
+
+		OrderTypes:           s.OrderTypes,
+		Permissions:          s.permissions(),
+		IcebergAllowed:       s.IcebergAllowed,
+		OCOAllowed:           s.OcoAllowed,
+		SpotTradingAllowed:   s.SpotTradingAllowed,
+		MarginTradingAllowed: s.MarginTradingAllowed,
+	}
+
+	for _, filter := range s.Filters {
+		filter.apply(&info.Filters)
+	}
+	if f := info.Filters.Price; f != nil {
+		info.MinPrice, info.MaxPrice, info.PriceStep = f.MinPrice, f.MaxPrice, f.TickSize
+	}
+	if f := info.Filters.LotSize; f != nil {
+		info.MinQuantity, info.MaxQuantity, info.QuantityStep = f.MinQuantity, f.MaxQuantity, f.StepSize
+	}
+	if f := info.Filters.Notional; f != nil {
+		info.MinNotional = f.MinNotional
+	}
+
+	return info
+}
+
+// permissions returns the distinct permissions from PermissionSets, or the
+// deprecated Permissions list if no sets are reported.
+func (s *SymbolInfo) permissions() []string {
+	var permissions []string
+	for _, set := range s.PermissionSets {
+		for _, p := range set {
+			if !slices.Contains(permissions, p) {
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	if permissions == nil {
+		return s.Permissions
+	}
+	return permissions
+}
+
+// hasPermission returns true if the symbol can be traded with the permission.
+func (s *SymbolInfo) hasPermission(permission string) bool {
+	return slices.Contains(s.permissions(), permission)
+}
+
+// SymbolFilter represents one entry of a symbol's filters.
+// Only the fields of its filterType are set.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#filters
+type SymbolFilter struct {
+	FilterType string `json:"filterType"`
+
+	// PRICE_FILTER
+	MinPrice domain.Decimal `json:"minPrice"`
+	MaxPrice domain.Decimal `json:"maxPrice"`
+	TickSize domain.Decimal `json:"tickSize"`
+
+	// LOT_SIZE, MARKET_LOT_SIZE
+	MinQty   domain.Decimal `json:"minQty"`
+	MaxQty   domain.Decimal `json:"maxQty"`
+	StepSize domain.Decimal `json:"stepSize"`
+
+	// NOTIONAL, MIN_NOTIONAL (applyToMarket)
+	MinNotional      domain.Decimal `json:"minNotional"`
+	MaxNotional      domain.Decimal `json:"maxNotional"`
+	ApplyToMarket    bool           `json:"applyToMarket"`
+	ApplyMinToMarket bool           `json:"applyMinToMarket"`
+	ApplyMaxToMarket bool           `json:"applyMaxToMarket"`
+	AvgPriceMins     int            `json:"avgPriceMins"`
+
+	// PERCENT_PRICE_BY_SIDE
+	BidMultiplierUp   domain.Decimal `json:"bidMultiplierUp"`
+	BidMultiplierDown domain.Decimal `json:"bidMultiplierDown"`
+	AskMultiplierUp   domain.Decimal `json:"askMultiplierUp"`
+	AskMultiplierDown domain.Decimal `json:"askMultiplierDown"`
+
+	// MAX_NUM_ORDERS
+	MaxNumOrders int `json:"maxNumOrders"`
+
+	// ICEBERG_PARTS
+	Limit int `json:"limit"`
+}
+
+// apply sets the domain filter this entry describes.
+// Filter types without a domain equivalent are ignored.
+func (f *SymbolFilter) apply(filters *domain.SymbolFilters) {
+	switch f.FilterType {
+	case "PRICE_FILTER":
+		filters.Price = &domain.PriceFilter{
+			MinPrice: f.MinPrice,
+			MaxPrice: f.MaxPrice,
+			TickSize: f.TickSize,
+		}
+	case "LOT_SIZE":
+		filters.LotSize = &domain.LotSizeFilter{
+			MinQuantity: f.MinQty,
+			MaxQuantity: f.MaxQty,
+			StepSize:    f.StepSize,
+		}
+	case "MARKET_LOT_SIZE":
+		filters.MarketLotSize = &domain.LotSizeFilter{
+			MinQuantity: f.MinQty,
+			MaxQuantity: f.MaxQty,
+			StepSize:    f.StepSize,
+		}
+	case "NOTIONAL":
+		filters.Notional = &domain.NotionalFilter{
+			MinNotional:      f.MinNotional,
+			MaxNotional:      f.MaxNotional,
+			ApplyMinToMarket: f.ApplyMinToMarket,
+			ApplyMaxToMarket: f.ApplyMaxToMarket,
+			AvgPriceMins:     f.AvgPriceMins,
+		}
+	case "MIN_NOTIONAL":
+		// Superseded by NOTIONAL; only used if NOTIONAL is absent
+		if filters.Notional == nil {
+			filters.Notional = &domain.NotionalFilter{
+				MinNotional:      f.MinNotional,
+				ApplyMinToMarket: f.ApplyToMarket,
+				AvgPriceMins:     f.AvgPriceMins,
+			}
+		}
+	case "PERCENT_PRICE_BY_SIDE":
+		filters.PercentPriceBySide = &domain.PercentPriceBySideFilter{
+			BidMultiplierUp:   f.BidMultiplierUp,
+			BidMultiplierDown: f.BidMultiplierDown,
+			AskMultiplierUp:   f.AskMultiplierUp,
+			AskMultiplierDown: f.AskMultiplierDown,
+			AvgPriceMins:      f.AvgPriceMins,
+		}
+	case "MAX_NUM_ORDERS":
+		filters.MaxNumOrders = &domain.MaxNumOrdersFilter{MaxNumOrders: f.MaxNumOrders}
+	case "ICEBERG_PARTS":
+		filters.IcebergParts = &domain.IcebergPartsFilter{Limit: f.Limit}
+	}
 }
 
 // GetAccount returns account information.
@@ -426,18 +884,16 @@ func (rc *RESTClient) GetAccount(ctx context.Context) (*AccountInfo, error) {
 
 	var result AccountInfo
 
-	resp, err := rc.client.R().
-		SetContext(ctx).
-		SetResult(&result).
-		Get(EAccount)
+	err := rc.doSigned(func() (*resty.Response, error) {
+		return rc.client.R().
+			SetContext(ctx).
+			SetResult(&result).
+			Get(EAccount)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if !resp.IsSuccess() {
-		return nil, rc.handleErrorResponse(resp)
-	}
-
 	return &result, nil
 }
 
@@ -1,12 +1,12 @@
 package binance
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,24 +18,36 @@ const (
 	MaxRecvWindow = 60000
 )
 
-// Signer handles HMAC-SHA256 signing for Binance API requests.
+// Signer handles signing for Binance API requests.
 // Documentation: https://binance-docs.github.io/apidocs/spot/en/#signed-trade-and-user_data-endpoint-security
 // Version: API v3 (verified 2026-02-16)
 //
 // Authentication method:
 //   - API key goes in X-MBX-APIKEY header (NOT in query params)
-//   - Signature is HMAC-SHA256 of the query string (including timestamp and recvWindow)
-//   - Timestamp must be in milliseconds
+//   - Signature is computed over the query string (including timestamp and recvWindow)
+//     by a KeySigner: HMAC-SHA256, RSA or Ed25519
+//   - Timestamp must be in milliseconds, within recvWindow of server time
 type Signer struct {
 	apiKey     string
-	apiSecret  string
+	key        KeySigner // nil if no secret was provided
 	recvWindow int64
+	now        func() int64 // Timestamp source in milliseconds
 }
 
-// NewSigner creates a new Signer for Binance API authentication.
+// NewSigner creates a new HMAC-SHA256 Signer for Binance API authentication.
 // If recvWindow is 0, DefaultRecvWindow (5000ms) is used.
 // If recvWindow exceeds MaxRecvWindow (60000ms), MaxRecvWindow is used.
 func NewSigner(apiKey, apiSecret string, recvWindow int64) *Signer {
+	var key KeySigner
+	if apiSecret != "" {
+		key = &HMACSigner{secret: []byte(apiSecret)}
+	}
+	return NewSignerWithKey(apiKey, key, recvWindow)
+}
+
+// NewSignerWithKey creates a new Signer that signs with key (see NewKeySigner).
+// recvWindow is bounded as in NewSigner.
+func NewSignerWithKey(apiKey string, key KeySigner, recvWindow int64) *Signer {
 	if recvWindow <= 0 {
 		recvWindow = DefaultRecvWindow
 	} else if recvWindow > MaxRecvWindow {
@@ -44,12 +56,19 @@ func NewSigner(apiKey, apiSecret string, recvWindow int64) *Signer {
 
 	return &Signer{
 		apiKey:     apiKey,
-		apiSecret:  apiSecret,
+		key:        key,
 		recvWindow: recvWindow,
+		now:        func() int64 { return time.Now().UnixMilli() },
 	}
 }
 
-// Sign adds timestamp and recvWindow to params, then computes HMAC-SHA256 signature.
+// SetClock sets the timestamp source, e.g. a synchronised exchange clock.
+// Must be called before the signer is used concurrently.
+func (s *Signer) SetClock(now func() int64) {
+	s.now = now
+}
+
+// Sign adds timestamp and recvWindow to params, then computes the signature.
 // Returns the timestamp used (milliseconds) and the signature.
 //
 // The signature is computed over the URL-encoded query string.
@@ -62,10 +81,10 @@ func NewSigner(apiKey, apiSecret string, recvWindow int64) *Signer {
 //	params.Set("side", "BUY")
 //	timestamp, signature := signer.Sign(params)
 //	// params now contains: symbol=BTCUSDT&side=BUY&timestamp=1234567890123&recvWindow=5000
-//	// signature is HMAC-SHA256 of "recvWindow=5000&side=BUY&symbol=BTCUSDT&timestamp=1234567890123"
+//	// signature is computed over "recvWindow=5000&side=BUY&symbol=BTCUSDT&timestamp=1234567890123"
 func (s *Signer) Sign(params url.Values) (timestamp int64, signature string) {
 	// Add timestamp in milliseconds
-	timestamp = time.Now().UnixMilli()
+	timestamp = s.now()
 	params.Set("timestamp", strconv.FormatInt(timestamp, 10))
 
 	// Add recvWindow
@@ -74,18 +93,51 @@ func (s *Signer) Sign(params url.Values) (timestamp int64, signature string) {
 	// Encode params (sorted alphabetically by Encode())
 	queryString := params.Encode()
 
-	// Compute HMAC-SHA256 signature
+	// Compute signature
 	signature = s.SignString(queryString)
 
 	return timestamp, signature
 }
 
-// SignString computes HMAC-SHA256 signature of the given query string.
+// StampParams adds timestamp and recvWindow to WebSocket API request params.
+// Requests on a session authenticated with session.logon need only these.
+func (s *Signer) StampParams(params map[string]any) {
+	params["timestamp"] = s.now()
+	params["recvWindow"] = s.recvWindow
+}
+
+// SignParams signs WebSocket API request params in place.
+// apiKey, timestamp and recvWindow are added, then the signature is computed
+// over the params sorted by key and joined as key=value pairs with '&'.
+// Unlike REST, values are not URL-encoded in the payload.
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/request-security
+func (s *Signer) SignParams(params map[string]any) {
+	delete(params, "signature")
+	params["apiKey"] = s.apiKey
+	s.StampParams(params)
+
+	keys := slices.Sorted(maps.Keys(params))
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + fmt.Sprint(params[key])
+	}
+	params["signature"] = s.SignString(strings.Join(pairs, "&"))
+}
+
+// SignString computes the signature of the given query string.
 // The query string should NOT include the signature parameter.
+// HMAC signatures are hex-encoded; RSA and Ed25519 signatures are base64-encoded
+// and must be URL-encoded when added to a query string.
 func (s *Signer) SignString(queryString string) string {
-	mac := hmac.New(sha256.New, []byte(s.apiSecret))
-	mac.Write([]byte(queryString))
-	return hex.EncodeToString(mac.Sum(nil))
+	return s.key.Sign([]byte(queryString))
+}
+
+// KeyType returns the signing key type (driver.KeyTypeHMAC, KeyTypeRSA or KeyTypeEd25519).
+func (s *Signer) KeyType() string {
+	if s.key == nil {
+		return ""
+	}
+	return s.key.KeyType()
 }
 
 // APIKey returns the API key for X-MBX-APIKEY header.
@@ -105,7 +157,7 @@ func (s *Signer) ValidateCredentials() error {
 	if s.apiKey == "" {
 		return fmt.Errorf("binance: API key is required")
 	}
-	if s.apiSecret == "" {
+	if s.key == nil {
 		return fmt.Errorf("binance: API secret is required")
 	}
 	return nil
@@ -25,10 +25,10 @@ func NewSubscriptionManager() *SubscriptionManager {
 
 // Subscribe adds a stream to subscriptions.
 // Returns true if this is a new subscription, false if already subscribed.
-// CRITICAL: Stream names MUST be lowercase for Binance.
+// CRITICAL: Market stream names MUST be lowercase for Binance.
 func (sm *SubscriptionManager) Subscribe(stream string) bool {
 	// Normalize to lowercase
-	stream = strings.ToLower(stream)
+	stream = normalizeStream(stream)
 
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -43,7 +43,7 @@ func (sm *SubscriptionManager) Subscribe(stream string) bool {
 // Unsubscribe removes a stream from subscriptions.
 // Returns true if the stream was subscribed, false otherwise.
 func (sm *SubscriptionManager) Unsubscribe(stream string) bool {
-	stream = strings.ToLower(stream)
+	stream = normalizeStream(stream)
 
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -57,7 +57,7 @@ func (sm *SubscriptionManager) Unsubscribe(stream string) bool {
 
 // IsSubscribed checks if a stream is subscribed.
 func (sm *SubscriptionManager) IsSubscribed(stream string) bool {
-	stream = strings.ToLower(stream)
+	stream = normalizeStream(stream)
 
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
@@ -78,6 +78,25 @@ func (sm *SubscriptionManager) Streams() []string {
 	return streams
 }
 
+// Missing returns the subscribed streams not present in streams.
+func (sm *SubscriptionManager) Missing(streams []string) []string {
+	have := make(map[string]bool, len(streams))
+	for _, stream := range streams {
+		have[normalizeStream(stream)] = true
+	}
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var missing []string
+	for stream := range sm.subscriptions {
+		if !have[stream] {
+			missing = append(missing, stream)
+		}
+	}
+	return missing
+}
+
 // Count returns the number of active subscriptions.
 func (sm *SubscriptionManager) Count() int {
 	sm.mu.RLock()
@@ -94,6 +113,17 @@ func (sm *SubscriptionManager) Clear() {
 	sm.subscriptions = make(map[string]bool)
 }
 
+// normalizeStream lowercases the symbol of market stream names.
+// listenKeys contain no "@" and are case-sensitive, so they are left as-is.
+// The stream suffix keeps its case: kline_1M (month) differs from kline_1m (minute).
+func normalizeStream(stream string) string {
+	idx := strings.Index(stream, "@")
+	if idx < 0 {
+		return stream
+	}
+	return strings.ToLower(stream[:idx]) + stream[idx:]
+}
+
 // StreamBuilder creates Binance WebSocket stream names.
 // Documentation: https://binance-docs.github.io/apidocs/spot/en/#websocket-market-streams
 // Stream names MUST be lowercase for Binance.
@@ -102,10 +132,10 @@ type StreamBuilder struct {
 }
 
 // NewStreamBuilder creates a StreamBuilder for the given symbol.
-// Symbol should be in exchange format (e.g., "BTCUSDT"), not normalized format.
+// Accepts both exchange ("BTCUSDT") and normalized ("BTC/USDT") formats.
 func NewStreamBuilder(symbol string) *StreamBuilder {
 	return &StreamBuilder{
-		symbol: strings.ToLower(symbol),
+		symbol: strings.ToLower(symbols.ExchangeSymbol(symbol)),
 	}
 }
 
@@ -186,8 +216,9 @@ func (sb *StreamBuilder) AggTrade() string {
 // Kline creates a kline/candlestick stream name.
 // Stream: <symbol>@kline_<interval>
 // Valid intervals: 1s, 1m, 3m, 5m, 15m, 30m, 1h, 2h, 4h, 6h, 8h, 12h, 1d, 3d, 1w, 1M
+// Intervals are case-sensitive (1m is one minute, 1M is one month).
 func (sb *StreamBuilder) Kline(interval string) string {
-	return sb.symbol + "@kline_" + strings.ToLower(interval)
+	return sb.symbol + "@kline_" + interval
 }
 
 // ForceOrder creates a liquidation order stream name.
@@ -267,5 +298,10 @@ func ParseStreamType(stream string) string {
 		return rest[:atAtIdx]
 	}
 
+	// Kline streams carry the interval: kline_<interval>
+	if strings.HasPrefix(rest, "kline_") {
+		return "kline"
+	}
+
 	return rest
 }
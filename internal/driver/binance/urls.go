@@ -3,6 +3,8 @@
 // API Version: v3 (verified 2026-02-16)
 package binance
 
+import "time"
+
 // Binance API base URLs
 const (
 	// BaseRestURL is the production REST API base URL
@@ -17,6 +19,10 @@ const (
 	TestnetWebSocketURL = "wss://testnet.binance.vision/ws"
 	// TestnetWebSocketCombinedURL is the testnet combined stream WebSocket URL
 	TestnetWebSocketCombinedURL = "wss://testnet.binance.vision/stream"
+	// BaseWebSocketAPIURL is the production WebSocket API (request/response) URL
+	BaseWebSocketAPIURL = "wss://ws-api.binance.com:443/ws-api/v3"
+	// TestnetWebSocketAPIURL is the testnet WebSocket API URL
+	TestnetWebSocketAPIURL = "wss://ws-api.testnet.binance.vision/ws-api/v3"
 )
 
 // Binance API v3 endpoints
@@ -38,8 +44,12 @@ const (
 	ETickerBook        = "/api/v3/ticker/bookTicker"
 	ESymbolPriceTicker = "/api/v3/ticker/price"
 	EAllBookTickers    = "/api/v3/ticker/bookTicker"
+	ERollingTicker     = "/api/v3/ticker"
+	ETradingDayTicker  = "/api/v3/ticker/tradingDay"
+	EKlines            = "/api/v3/klines"
 	EOpenOrders        = "/api/v3/openOrders"
 	EAllOrders         = "/api/v3/allOrders"
+	EMyTrades          = "/api/v3/myTrades"
 
 	// Order endpoints
 	ENewOrder            = "/api/v3/order"
@@ -60,40 +70,24 @@ const (
 	ESymbolInfo = "/api/v3/exchangeInfo"
 )
 
-// Endpoint weights for Binance API
+// Usage header prefixes. The suffix is the bucket interval (e.g., X-MBX-USED-WEIGHT-1M).
 // Documentation: https://binance-docs.github.io/apidocs/spot/en/#limits
-// Last verified: 2026-02-16
-var EndpointWeights = map[string]int{
-	// General endpoints
-	"/api/v3/ping":         1,
-	"/api/v3/time":         1,
-	"/api/v3/exchangeInfo": 20,
-	"/api/v3/account":      20,
-
-	// Market data endpoints
-	"/api/v3/depth":             5, // default, varies by limit
-	"/api/v3/trades":            1,
-	"/api/v3/historicalTrades":  5,
-	"/api/v3/ticker/24hr":       2, // per symbol
-	"/api/v3/ticker/price":      1, // per symbol
-	"/api/v3/ticker/bookTicker": 2, // per symbol
-
-	// Order endpoints
-	"/api/v3/order":      1, // POST (new order) = 1, GET (query) = 2, DELETE (cancel) = 1
-	"/api/v3/openOrders": 3, // per symbol, 6 if no symbol
-	"/api/v3/allOrders":  10,
+const (
+	HeaderUsedWeight = "X-MBX-USED-WEIGHT-"
+	HeaderOrderCount = "X-MBX-ORDER-COUNT-"
+)
 
-	// User data stream
-	"/api/v3/userDataStream": 1,
-}
+// Backoff defaults used when a rate limit response carries no Retry-After.
+const (
+	defaultRetryAfter = time.Second
+	defaultBanBackoff = 2 * time.Minute // Shortest Binance IP ban
+)
 
-// GetEndpointWeight returns the weight for a given endpoint.
-// For endpoints that vary by method (like /api/v3/order), returns the base weight.
-// Returns 1 as default for unknown endpoints (safe default).
-func GetEndpointWeight(endpoint string) int {
-	if weight, ok := EndpointWeights[endpoint]; ok {
-		return weight
-	}
-	// Default weight of 1 for unknown endpoints
-	return 1
+// OrderCountEndpoints holds the ORDERS units consumed by each order placement
+// endpoint (POST only). An OCO places two orders.
+var OrderCountEndpoints = map[string]int{
+	"/api/v3/order":               1,
+	"/api/v3/order/oco":           2,
+	"/api/v3/orderList/oco":       2,
+	"/api/v3/order/cancelReplace": 1,
 }
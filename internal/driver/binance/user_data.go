@@ -0,0 +1,350 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+const (
+	// ListenKeyKeepAlive is the interval between listenKey keepalives.
+	// A listenKey expires 60 minutes after creation or its last keepalive.
+	// Documentation: https://binance-docs.github.io/apidocs/spot/en/#listen-key-spot
+	ListenKeyKeepAlive = 30 * time.Minute
+
+	// listenKeyCloseTimeout bounds the DELETE sent on Stop.
+	listenKeyCloseTimeout = 5 * time.Second
+)
+
+// CreateListenKey starts a new user data stream.
+// API: POST /api/v3/userDataStream (API key only)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#listen-key-spot
+// Weight: 2
+func (rc *RESTClient) CreateListenKey(ctx context.Context) (string, error) {
+	if rc.signer == nil {
+		return "", fmt.Errorf("binance: API credentials required for CreateListenKey")
+	}
+
+	var result struct {
+		ListenKey string `json:"listenKey"`
+	}
+
+	resp, err := rc.client.R().
+		SetContext(ctx).
+		SetResult(&result).
+		Post(EUserDataStream)
+	if err != nil {
+		return "", err
+	}
+
+	if !resp.IsSuccess() {
+		return "", rc.handleErrorResponse(resp)
+	}
+
+	return result.ListenKey, nil
+}
+
+// KeepAliveListenKey extends a listenKey's validity by 60 minutes.
+// API: PUT /api/v3/userDataStream (API key only)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#listen-key-spot
+// Weight: 2
+// Returns a *errors.NotFoundError if the listenKey no longer exists (-1125).
+func (rc *RESTClient) KeepAliveListenKey(ctx context.Context, listenKey string) error {
+	resp, err := rc.client.R().
+		SetContext(ctx).
+		SetQueryParam("listenKey", listenKey).
+		Put(EUserDataStream)
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return rc.handleErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// CloseListenKey closes a user data stream.
+// API: DELETE /api/v3/userDataStream (API key only)
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#listen-key-spot
+// Weight: 2
+func (rc *RESTClient) CloseListenKey(ctx context.Context, listenKey string) error {
+	resp, err := rc.client.R().
+		SetContext(ctx).
+		SetQueryParam("listenKey", listenKey).
+		Delete(EUserDataStream)
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return rc.handleErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// UserDataStream manages the listenKey lifecycle and the user data WebSocket.
+//
+// Lifecycle:
+//   - Start creates a listenKey and connects a dedicated WebSocket to it
+//   - The listenKey is kept alive with a PUT every ListenKeyKeepAlive;
+//     keepalives failing on transport errors or rate limits are retried
+//     with backoff
+//   - When the exchange reports the listenKey gone, or sends a
+//     listenKeyExpired event, a new listenKey is created, retrying with
+//     backoff until it succeeds, and the WebSocket is re-subscribed to it
+//   - Stop closes the WebSocket and deletes the listenKey
+//
+// Keepalives and renewals run on one goroutine, so they never overlap.
+type UserDataStream struct {
+	rest *RESTClient
+	ws   *WSClient
+
+	listenKey string
+	keyMu     sync.Mutex
+	expired   chan string // listenKeyExpired events for the maintenance loop
+	started   atomic.Bool
+
+	onError func(err error)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewUserDataStream creates a user data stream using rest for listenKey management.
+// The REST client must have API credentials.
+func NewUserDataStream(rest *RESTClient, cfg WSConfig) *UserDataStream {
+	s := &UserDataStream{
+		rest:    rest,
+		ws:      NewWSClient(cfg),
+		expired: make(chan string, 1),
+	}
+	s.ws.OnListenKeyExpired(s.handleExpired)
+	return s
+}
+
+// OnOrder sets the order update callback (executionReport).
+func (s *UserDataStream) OnOrder(fn func(order *domain.Order)) {
+	s.ws.OnOrder(fn)
+}
+
+// OnBalance sets the balance callback (outboundAccountPosition).
+func (s *UserDataStream) OnBalance(fn func(balance *domain.Balance)) {
+	s.ws.OnBalance(fn)
+}
+
+// OnBalanceDelta sets the balance delta callback (balanceUpdate).
+func (s *UserDataStream) OnBalanceDelta(fn func(asset string, delta domain.Decimal, at time.Time)) {
+	s.ws.OnBalanceDelta(fn)
+}
+
+// OnConnect sets the connect callback.
+func (s *UserDataStream) OnConnect(fn func()) {
+	s.ws.OnConnect(fn)
+}
+
+// OnDisconnect sets the disconnect callback.
+func (s *UserDataStream) OnDisconnect(fn func(err error)) {
+	s.ws.OnDisconnect(fn)
+}
+
+// OnError sets the callback for listenKey maintenance failures.
+func (s *UserDataStream) OnError(fn func(err error)) {
+	s.onError = fn
+}
+
+// Start creates a listenKey, connects the WebSocket and starts keepalives.
+// On failure nothing is left behind, so Start can be called again.
+func (s *UserDataStream) Start(ctx context.Context) error {
+	listenKey, err := s.rest.CreateListenKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.keyMu.Lock()
+	s.listenKey = listenKey
+	s.keyMu.Unlock()
+
+	err = s.ws.Subscribe(UserData(listenKey))
+	if err == nil {
+		err = s.ws.Connect()
+	}
+	if err != nil {
+		s.keyMu.Lock()
+		s.listenKey = ""
+		s.keyMu.Unlock()
+		s.ws.Unsubscribe(UserData(listenKey))
+
+		closeCtx, cancel := context.WithTimeout(context.Background(), listenKeyCloseTimeout)
+		defer cancel()
+		_ = s.rest.CloseListenKey(closeCtx, listenKey)
+		return err
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Go(s.keepAliveLoop)
+	s.started.Store(true)
+
+	return nil
+}
+
+// Started reports whether Start has succeeded.
+func (s *UserDataStream) Started() bool {
+	return s.started.Load()
+}
+
+// Stop stops keepalives, closes the WebSocket and deletes the listenKey.
+func (s *UserDataStream) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+
+	err := s.ws.Close()
+
+	s.keyMu.Lock()
+	listenKey := s.listenKey
+	s.listenKey = ""
+	s.keyMu.Unlock()
+
+	if listenKey != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), listenKeyCloseTimeout)
+		defer cancel()
+		// Best effort: an unclosed key simply expires after 60 minutes
+		_ = s.rest.CloseListenKey(ctx, listenKey)
+	}
+
+	return err
+}
+
+// ListenKey returns the current listenKey.
+func (s *UserDataStream) ListenKey() string {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+	return s.listenKey
+}
+
+// IsConnected returns true if the user data WebSocket is connected.
+func (s *UserDataStream) IsConnected() bool {
+	return s.ws.IsConnected()
+}
+
+// keepAliveLoop sends a keepalive every ListenKeyKeepAlive and renews
+// expired listenKeys, until Stop.
+func (s *UserDataStream) keepAliveLoop() {
+	ticker := time.NewTicker(ListenKeyKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case expired := <-s.expired:
+			s.renew(expired)
+		case <-ticker.C:
+			if listenKey := s.ListenKey(); listenKey != "" {
+				s.keepAlive(listenKey)
+			}
+		}
+	}
+}
+
+// keepAlive extends listenKey, retrying with backoff until it succeeds or
+// the stream stops. Only a key the exchange reports gone is renewed; after
+// a transport error or rate limit the key may still be valid.
+func (s *UserDataStream) keepAlive(listenKey string) {
+	for attempt := 1; ; attempt++ {
+		err := s.rest.KeepAliveListenKey(s.ctx, listenKey)
+		if err == nil || s.ctx.Err() != nil {
+			return
+		}
+
+		var notFound *errors.NotFoundError
+		if errors.As(err, &notFound) {
+			s.reportError(errors.NewExchangeError(exchange, "listen_key_keepalive", "listenKey no longer exists, renewing", err))
+			s.renew(listenKey)
+			return
+		}
+
+		s.reportError(errors.NewExchangeError(exchange, "listen_key_keepalive", "keepalive failed, retrying", err))
+		if !s.wait(attempt) {
+			return
+		}
+	}
+}
+
+// handleExpired handles listenKeyExpired events from the WebSocket.
+// Renewal waits for subscription acks delivered by the read loop, so it is
+// handed to the maintenance loop.
+func (s *UserDataStream) handleExpired(listenKey string) {
+	select {
+	case s.expired <- listenKey:
+	default: // A renewal is already queued
+	}
+}
+
+// renew replaces an expired listenKey and re-subscribes the WebSocket.
+// Creating the key is retried with backoff until it succeeds or the stream
+// stops. It is a no-op if expired has already been replaced.
+func (s *UserDataStream) renew(expired string) {
+	var listenKey string
+	for attempt := 1; ; attempt++ {
+		if s.ListenKey() != expired {
+			return
+		}
+
+		var err error
+		listenKey, err = s.rest.CreateListenKey(s.ctx)
+		if err == nil {
+			break
+		}
+		if s.ctx.Err() != nil {
+			return
+		}
+		s.reportError(errors.NewExchangeError(exchange, "listen_key_renew", "failed to create listenKey, retrying", err))
+		if !s.wait(attempt) {
+			return
+		}
+	}
+
+	s.keyMu.Lock()
+	s.listenKey = listenKey
+	s.keyMu.Unlock()
+
+	s.ws.Unsubscribe(UserData(expired))
+	if err := s.ws.Subscribe(UserData(listenKey)); err != nil {
+		s.reportError(errors.NewExchangeError(exchange, "listen_key_renew", "failed to subscribe new listenKey", err))
+	}
+}
+
+// wait sleeps for the backoff of a failed attempt.
+// Returns false if the stream stopped meanwhile.
+func (s *UserDataStream) wait(attempt int) bool {
+	timer := time.NewTimer(reconnectDelay(DefaultReconnectConfig(), attempt))
+	defer timer.Stop()
+
+	select {
+	case <-s.ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// reportError forwards a maintenance error to the error callback.
+func (s *UserDataStream) reportError(err error) {
+	if s.onError == nil {
+		return
+	}
+	s.ws.safeCallback(func() {
+		s.onError(err)
+	})
+}
@@ -0,0 +1,154 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// endpointWeights holds fixed request weights keyed by "METHOD path".
+// Endpoints whose weight depends on parameters are handled in EndpointWeight.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#limits
+// Last verified: 2026-02-16
+var endpointWeights = map[string]int{
+	// General endpoints
+	"GET /api/v3/ping":         1,
+	"GET /api/v3/time":         1,
+	"GET /api/v3/exchangeInfo": 20,
+	"GET /api/v3/account":      20,
+
+	// Market data endpoints
+	"GET /api/v3/trades":           25,
+	"GET /api/v3/historicalTrades": 25,
+	"GET /api/v3/aggTrades":        2,
+	"GET /api/v3/klines":           2,
+	"GET /api/v3/uiKlines":         2,
+	"GET /api/v3/avgPrice":         2,
+
+	// Order endpoints
+	"POST /api/v3/order":               1,
+	"GET /api/v3/order":                4,
+	"DELETE /api/v3/order":             1,
+	"POST /api/v3/order/test":          1,
+	"POST /api/v3/order/cancelReplace": 1,
+	"DELETE /api/v3/openOrders":        1,
+	"GET /api/v3/allOrders":            20,
+	"POST /api/v3/order/oco":           1,
+	"POST /api/v3/orderList/oco":       1,
+	"GET /api/v3/orderList":            4,
+	"DELETE /api/v3/orderList":         1,
+	"GET /api/v3/allOrderList":         20,
+	"GET /api/v3/openOrderList":        6,
+
+	// User data stream
+	"POST /api/v3/userDataStream":   2,
+	"PUT /api/v3/userDataStream":    2,
+	"DELETE /api/v3/userDataStream": 2,
+}
+
+// EndpointWeight returns the request weight of a call, matching what the
+// server adds to X-MBX-USED-WEIGHT-1M.
+// path is the endpoint path without base URL or query; params are the query parameters.
+// Returns 1 for unknown endpoints (safe default).
+//
+// Parameter-dependent weights:
+//   - GET /api/v3/depth: by limit tier (5, 25, 50, 250)
+//   - GET /api/v3/ticker/24hr: by symbol count (2, 40, 80)
+//   - GET /api/v3/ticker/price, /ticker/bookTicker: 2 for one symbol, 4 otherwise
+//   - GET /api/v3/ticker, /ticker/tradingDay: 4 per symbol, capped at 200
+//   - GET /api/v3/openOrders: 6 with a symbol, 80 without
+//   - GET /api/v3/myTrades: 5 with an orderId, 20 otherwise
+func EndpointWeight(method, path string, params url.Values) int {
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	if method == http.MethodGet {
+		switch path {
+		case EDepth:
+			return depthWeight(params.Get("limit"))
+		case ETicker:
+			return ticker24hrWeight(params)
+		case ETickerPrice, ETickerBook:
+			if params.Get("symbol") != "" {
+				return 2
+			}
+			return 4
+		case ERollingTicker, ETradingDayTicker:
+			return min(4*max(symbolCount(params), 1), 200)
+		case EOpenOrders:
+			if params.Get("symbol") != "" {
+				return 6
+			}
+			return 80
+		case EMyTrades:
+			if params.Get("orderId") != "" {
+				return 5
+			}
+			return 20
+		}
+	}
+
+	if weight, ok := endpointWeights[method+" "+path]; ok {
+		return weight
+	}
+	return 1
+}
+
+// depthWeight returns the /api/v3/depth weight for a limit (default 100).
+func depthWeight(limit string) int {
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		n = 100
+	}
+
+	switch {
+	case n <= 100:
+		return 5
+	case n <= 500:
+		return 25
+	case n <= 1000:
+		return 50
+	default:
+		return 250
+	}
+}
+
+// ticker24hrWeight returns the /api/v3/ticker/24hr weight.
+// Without symbol or symbols, all symbols are returned.
+func ticker24hrWeight(params url.Values) int {
+	if params.Get("symbol") != "" {
+		return 2
+	}
+
+	switch n := symbolCount(params); {
+	case n == 0:
+		return 80
+	case n <= 20:
+		return 2
+	case n <= 100:
+		return 40
+	default:
+		return 80
+	}
+}
+
+// symbolCount returns the number of symbols requested via symbol or symbols.
+// symbols is a JSON array, e.g. ["BTCUSDT","BNBUSDT"].
+func symbolCount(params url.Values) int {
+	if params.Get("symbol") != "" {
+		return 1
+	}
+
+	raw := params.Get("symbols")
+	if raw == "" {
+		return 0
+	}
+	var symbols []string
+	if err := json.Unmarshal([]byte(raw), &symbols); err == nil {
+		return len(symbols)
+	}
+	return strings.Count(raw, ",") + 1
+}
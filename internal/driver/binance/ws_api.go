@@ -0,0 +1,692 @@
+package binance
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/ratelimit"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+	"github.com/lxzan/gws"
+)
+
+// WebSocket API methods.
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/trading-requests
+const (
+	wsAPISessionLogon      = "session.logon"
+	wsAPIOrderPlace        = "order.place"
+	wsAPIOrderCancel       = "order.cancel"
+	wsAPIOrderStatus       = "order.status"
+	wsAPIUserDataSubscribe = "userDataStream.subscribe"
+	wsAPIEventTerminated   = "eventStreamTerminated"
+)
+
+// wsAPIPongTimeout is how long the connection may go without traffic.
+// The server pings every 20 seconds and disconnects after a minute without a pong.
+const wsAPIPongTimeout = time.Minute
+
+// wsAPICosts holds the rate limit cost of each WebSocket API method.
+// WebSocket API requests share the REQUEST_WEIGHT and ORDERS limits with REST.
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/rate-limits
+// Last verified: 2026-02-16
+var wsAPICosts = map[string]ratelimit.Cost{
+	wsAPISessionLogon:      {Weight: 2},
+	wsAPIOrderPlace:        {Weight: 1, Orders: 1},
+	wsAPIOrderCancel:       {Weight: 1},
+	wsAPIOrderStatus:       {Weight: 4},
+	wsAPIUserDataSubscribe: {Weight: 2},
+}
+
+// WSAPIClient sends requests over the Binance WebSocket API, a request/response
+// protocol on a persistent connection that avoids a TLS handshake and HTTP
+// round trip per order.
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/general-api-information
+//
+// Requests are correlated with responses by ID. Every request passes the
+// driver's backoff gate and shared rate limiter, and usage reported in each
+// response's rateLimits reconciles the limiter.
+//
+// With an Ed25519 key the session is authenticated once with session.logon and
+// requests are sent unsigned; otherwise every request is signed.
+//
+// IMPORTANT: When the connection drops, requests awaiting a response fail
+// immediately. For order.place and order.cancel the error is not retryable:
+// the server may have executed the request, so the order must be queried
+// before it is resent.
+//
+// After a successful Connect, the client reconnects with backoff, logs on
+// again and restores the user data subscription.
+type WSAPIClient struct {
+	rest      *RESTClient
+	config    WSConfig
+	callbacks WSClientCallbacks
+
+	// Connection state
+	conn         *gws.Conn
+	connMu       sync.RWMutex
+	connected    atomic.Bool
+	closed       atomic.Bool
+	started      atomic.Bool // Connect succeeded; reconnect on close
+	reconnecting atomic.Bool
+	loggedOn     atomic.Bool // Session authenticated with session.logon
+	userData     atomic.Bool // User data subscription requested
+
+	// Requests awaiting a response, keyed by request ID
+	reqID     atomic.Int64
+	pending   map[int64]chan *WSAPIResponse
+	pendingMu sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewWSAPIClient creates a WebSocket API client.
+// rest provides the credentials, clock, rate limiter and backoff gate, which
+// are shared with REST traffic.
+func NewWSAPIClient(rest *RESTClient, cfg WSConfig) *WSAPIClient {
+	if cfg.PingInterval == 0 {
+		cfg.PingInterval = 20 * time.Second
+	}
+	if cfg.Reconnect.InitialDelay == 0 {
+		cfg.Reconnect = DefaultReconnectConfig()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WSAPIClient{
+		rest:    rest,
+		config:  cfg,
+		pending: make(map[int64]chan *WSAPIResponse),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// OnOrder sets the order update callback (executionReport).
+func (c *WSAPIClient) OnOrder(fn func(order *domain.Order)) {
+	c.callbacks.OnOrder = fn
+}
+
+// OnBalance sets the balance callback (outboundAccountPosition).
+func (c *WSAPIClient) OnBalance(fn func(balance *domain.Balance)) {
+	c.callbacks.OnBalance = fn
+}
+
+// OnBalanceDelta sets the balance delta callback (balanceUpdate).
+func (c *WSAPIClient) OnBalanceDelta(fn func(asset string, delta domain.Decimal, at time.Time)) {
+	c.callbacks.OnBalanceDelta = fn
+}
+
+// OnConnect sets the connect callback, called once the session is
+// authenticated and the user data subscription restored.
+func (c *WSAPIClient) OnConnect(fn func()) {
+	c.callbacks.OnConnect = fn
+}
+
+// OnDisconnect sets the disconnect callback.
+func (c *WSAPIClient) OnDisconnect(fn func(err error)) {
+	c.callbacks.OnDisconnect = fn
+}
+
+// OnError sets the callback for background failures (reconnects, resubscribes).
+func (c *WSAPIClient) OnError(fn func(err error)) {
+	c.callbacks.OnError = fn
+}
+
+// url returns the WebSocket API URL.
+func (c *WSAPIClient) url() string {
+	if c.config.BaseURL != "" {
+		return c.config.BaseURL
+	}
+	if c.config.Testnet {
+		return TestnetWebSocketAPIURL
+	}
+	return BaseWebSocketAPIURL
+}
+
+// Connect dials the WebSocket API and, with an Ed25519 key, logs on.
+func (c *WSAPIClient) Connect(ctx context.Context) error {
+	if c.closed.Load() {
+		return errors.NewExchangeError(exchange, "connect", "client is closed", nil)
+	}
+	if c.rest.signer == nil {
+		return errors.NewExchangeError(exchange, "connect", "API credentials required for the WebSocket API", nil)
+	}
+
+	// Dialing during a rate limit backoff or IP ban would extend it
+	if err := c.rest.gate.Allow(); err != nil {
+		return err
+	}
+
+	if err := c.establish(ctx); err != nil {
+		return err
+	}
+	c.started.Store(true)
+	return nil
+}
+
+// establish dials, authenticates the session and restores the user data subscription.
+func (c *WSAPIClient) establish(ctx context.Context) error {
+	if err := c.dial(); err != nil {
+		return err
+	}
+
+	if c.rest.signer.KeyType() == driver.KeyTypeEd25519 {
+		if err := c.logon(ctx); err != nil {
+			c.disconnect()
+			return err
+		}
+	}
+	if c.userData.Load() {
+		if err := c.subscribeUserData(ctx); err != nil {
+			c.disconnect()
+			return err
+		}
+	}
+
+	c.safeCallback(func() {
+		if c.callbacks.OnConnect != nil {
+			c.callbacks.OnConnect()
+		}
+	})
+	return nil
+}
+
+// dial opens the connection and starts the read loop.
+func (c *WSAPIClient) dial() error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	option := &gws.ClientOption{
+		Addr: c.url(),
+		TlsConfig: &tls.Config{
+			InsecureSkipVerify: false,
+		},
+	}
+
+	conn, _, err := gws.NewClient(c, option)
+	if err != nil {
+		return errors.NewConnectionError(exchange, c.url(), err.Error(), true)
+	}
+
+	c.conn = conn
+	c.connected.Store(true)
+	go conn.ReadLoop()
+	return nil
+}
+
+// disconnect closes the current connection. OnClose fails pending requests.
+func (c *WSAPIClient) disconnect() {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.conn != nil {
+		c.conn.WriteClose(1000, nil)
+		c.conn = nil
+	}
+}
+
+// Close permanently closes the client.
+// Requests awaiting a response fail as on a dropped connection.
+func (c *WSAPIClient) Close() error {
+	if c.closed.Swap(true) {
+		return nil
+	}
+
+	c.cancel()
+	c.connected.Store(false)
+	c.disconnect()
+	c.failPending()
+	return nil
+}
+
+// IsConnected returns true if the WebSocket API connection is open.
+func (c *WSAPIClient) IsConnected() bool {
+	return c.connected.Load()
+}
+
+// logon authenticates the session so later requests need no signature.
+// API: session.logon (Ed25519 keys only)
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/authentication-requests
+// Weight: 2
+func (c *WSAPIClient) logon(ctx context.Context) error {
+	if err := c.request(ctx, wsAPISessionLogon, map[string]any{}, true, nil); err != nil {
+		return err
+	}
+	c.loggedOn.Store(true)
+	return nil
+}
+
+// SubscribeUserData subscribes to the user data stream on this connection.
+// Order and balance events are delivered to the callbacks, and the
+// subscription is restored after a reconnect.
+// API: userDataStream.subscribe (requires session.logon, so an Ed25519 key)
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/user-data-stream-requests
+// Weight: 2
+func (c *WSAPIClient) SubscribeUserData(ctx context.Context) error {
+	if !c.loggedOn.Load() {
+		return errors.NewExchangeError(exchange, wsAPIUserDataSubscribe, "requires a session logged on with an Ed25519 key", nil)
+	}
+	if err := c.subscribeUserData(ctx); err != nil {
+		return err
+	}
+	c.userData.Store(true)
+	return nil
+}
+
+// subscribeUserData sends userDataStream.subscribe.
+func (c *WSAPIClient) subscribeUserData(ctx context.Context) error {
+	return c.request(ctx, wsAPIUserDataSubscribe, nil, false, nil)
+}
+
+// PlaceOrder places a new order.
+// API: order.place
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/trading-requests#place-new-order-trade
+// Weight: 1
+//
+// Parameters and response match RESTClient.PlaceOrder.
+func (c *WSAPIClient) PlaceOrder(ctx context.Context, req *domain.OrderRequest) (*domain.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var result OrderResponse
+	if err := c.request(ctx, wsAPIOrderPlace, wsAPIParams(c.rest.newOrderParams(req)), true, &result); err != nil {
+		return nil, err
+	}
+	return result.placedOrder(req)
+}
+
+// CancelOrder cancels an active order by order ID or client order ID.
+// API: order.cancel
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/trading-requests#cancel-order-trade
+// Weight: 1
+func (c *WSAPIClient) CancelOrder(ctx context.Context, req *domain.CancelRequest) (*domain.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var result OrderResponse
+	if err := c.request(ctx, wsAPIOrderCancel, wsAPIParams(cancelOrderParams(req)), true, &result); err != nil {
+		return nil, err
+	}
+	return result.ToDomain()
+}
+
+// GetOrder returns an order by exchange order ID.
+// API: order.status
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/account-requests#query-order-user_data
+// Weight: 4
+func (c *WSAPIClient) GetOrder(ctx context.Context, symbol, orderID string) (*domain.Order, error) {
+	params := wsAPIParams(map[string]string{
+		"symbol":  symbols.ExchangeSymbol(symbol),
+		"orderId": orderID,
+	})
+
+	var result OrderResponse
+	if err := c.request(ctx, wsAPIOrderStatus, params, true, &result); err != nil {
+		return nil, err
+	}
+	return result.ToDomain()
+}
+
+// wsAPIParams converts REST query parameters to WebSocket API params.
+// Order IDs are integers in the WebSocket API; other values are sent as strings.
+func wsAPIParams(params map[string]string) map[string]any {
+	out := make(map[string]any, len(params))
+	for key, value := range params {
+		out[key] = value
+		if key == "orderId" {
+			if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+				out[key] = id
+			}
+		}
+	}
+	return out
+}
+
+// request sends a request and decodes its result into result (if non-nil).
+// If the server rejects the timestamp (-1021), the clock is resynced and the
+// request sent once more; the server did not act on the rejected request.
+func (c *WSAPIClient) request(ctx context.Context, method string, params map[string]any, signed bool, result any) error {
+	err := c.send(ctx, method, params, signed, result)
+
+	var clockErr *errors.ClockSyncError
+	if !signed || !errors.As(err, &clockErr) {
+		return err
+	}
+	// A ClockSyncError from Sync still applies the measured offset
+	if syncErr := c.rest.clock.Sync(); syncErr != nil && !errors.As(syncErr, &clockErr) {
+		return err
+	}
+	return c.send(ctx, method, params, signed, result)
+}
+
+// send performs a single request/response exchange.
+// signed requests are stamped with the synchronised time, and signed unless
+// the session is logged on.
+func (c *WSAPIClient) send(ctx context.Context, method string, params map[string]any, signed bool, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, wsRequestTimeout)
+	defer cancel()
+
+	if !c.connected.Load() {
+		return errors.NewConnectionError(exchange, c.url(), method+" not sent: not connected", true)
+	}
+
+	// Hold back every request during a rate limit backoff or IP ban
+	if err := c.rest.gate.Enter(ctx); err != nil {
+		return err
+	}
+	cost, ok := wsAPICosts[method]
+	if !ok {
+		cost = ratelimit.Cost{Weight: 1}
+	}
+	if err := c.rest.rateLimiter.Wait(ctx, cost); err != nil {
+		return fmt.Errorf("binance: rate limit wait failed: %w", err)
+	}
+
+	// Copy so a retry is stamped afresh
+	req := WSAPIRequest{ID: c.reqID.Add(1), Method: method}
+	if params != nil || signed {
+		req.Params = make(map[string]any, len(params)+4)
+		for key, value := range params {
+			req.Params[key] = value
+		}
+	}
+	if signed {
+		if c.loggedOn.Load() {
+			c.rest.signer.StampParams(req.Params)
+		} else {
+			c.rest.signer.SignParams(req.Params)
+		}
+	}
+
+	ch := make(chan *WSAPIResponse, 1)
+	c.pendingMu.Lock()
+	c.pending[req.ID] = ch
+	c.pendingMu.Unlock()
+
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, req.ID)
+		c.pendingMu.Unlock()
+	}()
+
+	if err := c.write(req); err != nil {
+		return err
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return c.inFlightError(method, "connection closed before "+method+" response")
+		}
+		return c.handleResponse(resp, result)
+	case <-ctx.Done():
+		return c.inFlightError(method, method+" response timed out")
+	}
+}
+
+// inFlightError returns the error for a request whose response never arrived.
+// Order placement and cancellation may have been executed, so they are not
+// retryable: resending could place a duplicate order.
+func (c *WSAPIClient) inFlightError(method, msg string) error {
+	switch method {
+	case wsAPIOrderPlace, wsAPIOrderCancel:
+		return errors.NewConnectionError(exchange, c.url(), msg+"; order state unknown, query before retrying", false)
+	default:
+		return errors.NewConnectionError(exchange, c.url(), msg, true)
+	}
+}
+
+// handleResponse reconciles rate limit usage and converts the response.
+func (c *WSAPIClient) handleResponse(resp *WSAPIResponse, result any) error {
+	for _, rl := range resp.RateLimits {
+		if limit, ok := rl.ToLimit(); ok {
+			c.rest.rateLimiter.Reconcile(limit.Type, limit.Interval, rl.Count)
+		}
+	}
+
+	if resp.Error != nil {
+		var retryAfter time.Duration
+		if resp.Error.Data != nil && resp.Error.Data.RetryAfter > 0 {
+			retryAfter = max(time.Until(time.UnixMilli(resp.Error.Data.RetryAfter)), 0)
+		}
+		err := c.rest.createBinanceError(resp.Status, resp.Error.Code, resp.Error.Msg, retryAfter)
+
+		// IP-level limits close the gate for REST and WebSocket traffic alike
+		if resp.Status == http.StatusTeapot || (resp.Status == http.StatusTooManyRequests && retryAfter > 0) {
+			c.rest.backoff(err)
+		}
+		return err
+	}
+
+	if result == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("binance: parse %s response: %w", resp.Result, err)
+	}
+	return nil
+}
+
+// write marshals and sends a request on the current connection.
+func (c *WSAPIClient) write(req WSAPIRequest) error {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	if conn == nil || !c.connected.Load() {
+		return errors.NewConnectionError(exchange, c.url(), req.Method+" not sent: not connected", true)
+	}
+
+	if err := conn.WriteMessage(gws.OpcodeText, payload); err != nil {
+		return errors.NewConnectionError(exchange, c.url(), err.Error(), true)
+	}
+	return nil
+}
+
+// failPending fails every request awaiting a response.
+func (c *WSAPIClient) failPending() {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+}
+
+// OnOpen implements gws.EventHandler.
+func (c *WSAPIClient) OnOpen(socket *gws.Conn) {
+	socket.SetDeadline(time.Now().Add(wsAPIPongTimeout))
+}
+
+// OnClose implements gws.EventHandler.
+// Pending requests fail, and the connection is re-established unless closed.
+func (c *WSAPIClient) OnClose(socket *gws.Conn, err error) {
+	c.connMu.Lock()
+	stale := c.conn != nil && c.conn != socket
+	if c.conn == socket {
+		c.conn = nil
+	}
+	c.connMu.Unlock()
+	if stale {
+		return // A replacement connection is already open
+	}
+
+	c.connected.Store(false)
+	c.loggedOn.Store(false)
+	c.failPending()
+
+	c.safeCallback(func() {
+		if c.callbacks.OnDisconnect != nil {
+			c.callbacks.OnDisconnect(err)
+		}
+	})
+
+	if c.started.Load() && !c.closed.Load() {
+		go c.reconnect()
+	}
+}
+
+// OnPing implements gws.EventHandler.
+func (c *WSAPIClient) OnPing(socket *gws.Conn, payload []byte) {
+	socket.SetDeadline(time.Now().Add(wsAPIPongTimeout))
+	socket.WritePong(payload)
+}
+
+// OnPong implements gws.EventHandler.
+func (c *WSAPIClient) OnPong(socket *gws.Conn, payload []byte) {
+	socket.SetDeadline(time.Now().Add(wsAPIPongTimeout))
+}
+
+// OnMessage implements gws.EventHandler.
+// Messages are either responses (with an id) or user data events.
+func (c *WSAPIClient) OnMessage(socket *gws.Conn, message *gws.Message) {
+	defer message.Close()
+
+	socket.SetDeadline(time.Now().Add(wsAPIPongTimeout))
+
+	data := message.Bytes()
+	if len(data) == 0 {
+		return
+	}
+
+	var event WSAPIEvent
+	if err := json.Unmarshal(data, &event); err == nil && len(event.Event) > 0 {
+		c.routeEvent(event.Event)
+		return
+	}
+
+	var resp WSAPIResponse
+	if err := json.Unmarshal(data, &resp); err != nil || resp.ID == 0 {
+		return
+	}
+
+	c.pendingMu.Lock()
+	ch, ok := c.pending[resp.ID]
+	delete(c.pending, resp.ID)
+	c.pendingMu.Unlock()
+
+	if ok {
+		ch <- &resp
+	}
+}
+
+// routeEvent dispatches a user data event to its callback.
+func (c *WSAPIClient) routeEvent(data []byte) {
+	var event struct {
+		EventType string `json:"e"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return
+	}
+
+	switch event.EventType {
+	case "executionReport":
+		var update WSOrderUpdate
+		if c.callbacks.OnOrder == nil || json.Unmarshal(data, &update) != nil {
+			return
+		}
+		if order, err := update.ToDomain(exchange); err == nil {
+			c.safeCallback(func() { c.callbacks.OnOrder(order) })
+		}
+	case "outboundAccountPosition":
+		var position WSAccountPosition
+		if c.callbacks.OnBalance == nil || json.Unmarshal(data, &position) != nil {
+			return
+		}
+		balances, err := position.ToDomain(exchange)
+		if err != nil {
+			return
+		}
+		for _, balance := range balances {
+			c.safeCallback(func() { c.callbacks.OnBalance(balance) })
+		}
+	case "balanceUpdate":
+		var update WSBalanceUpdate
+		if c.callbacks.OnBalanceDelta == nil || json.Unmarshal(data, &update) != nil {
+			return
+		}
+		if delta, err := update.Delta(); err == nil {
+			c.safeCallback(func() {
+				c.callbacks.OnBalanceDelta(update.Asset, delta, time.UnixMilli(update.ClearTime))
+			})
+		}
+	case wsAPIEventTerminated:
+		// The server ended the subscription (e.g. the session logged out)
+		if c.userData.Load() {
+			go c.resubscribeUserData()
+		}
+	}
+}
+
+// resubscribeUserData restores a subscription terminated by the server.
+func (c *WSAPIClient) resubscribeUserData() {
+	if err := c.subscribeUserData(c.ctx); err != nil {
+		c.reportError(err)
+	}
+}
+
+// reconnect re-establishes the connection with exponential backoff.
+func (c *WSAPIClient) reconnect() {
+	if c.reconnecting.Swap(true) {
+		return
+	}
+	defer c.reconnecting.Store(false)
+
+	for attempt := 1; ; attempt++ {
+		if c.closed.Load() || c.ctx.Err() != nil {
+			return
+		}
+
+		if limit := c.config.Reconnect.MaxAttempts; limit > 0 && attempt > limit {
+			c.reportError(errors.NewWebSocketReconnectError(exchange, c.url(), "max reconnection attempts exceeded", attempt, limit))
+			return
+		}
+
+		time.Sleep(reconnectDelay(c.config.Reconnect, attempt))
+
+		// Hold reconnects until any rate limit backoff or IP ban lifts
+		if err := c.rest.gate.Wait(c.ctx); err != nil {
+			continue
+		}
+
+		if err := c.establish(c.ctx); err != nil {
+			c.reportError(err)
+			continue
+		}
+		return
+	}
+}
+
+// reportError forwards a background error to the error callback.
+func (c *WSAPIClient) reportError(err error) {
+	if c.callbacks.OnError == nil {
+		return
+	}
+	c.safeCallback(func() {
+		c.callbacks.OnError(err)
+	})
+}
+
+// safeCallback executes a callback with panic recovery.
+func (c *WSAPIClient) safeCallback(fn func()) {
+	defer func() {
+		_ = recover()
+	}()
+	fn()
+}
@@ -7,14 +7,17 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/lilwiggy/ex-act/internal/ratelimit"
 	"github.com/lilwiggy/ex-act/pkg/domain"
 	"github.com/lilwiggy/ex-act/pkg/errors"
 	"github.com/lxzan/gws"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -22,6 +25,19 @@ const (
 
 	// WebSocket URL paths
 	wsCombinedPath = "?streams="
+
+	// Live subscription methods
+	// Documentation: https://binance-docs.github.io/apidocs/spot/en/#live-subscribing-unsubscribing-to-streams
+	methodSubscribe         = "SUBSCRIBE"
+	methodUnsubscribe       = "UNSUBSCRIBE"
+	methodListSubscriptions = "LIST_SUBSCRIPTIONS"
+
+	// wsMaxMessagesPerSecond is the limit on messages sent to the server.
+	// Pings count towards it; exceeding it disconnects the client.
+	wsMaxMessagesPerSecond = 5
+
+	// wsRequestTimeout bounds the wait for a method response.
+	wsRequestTimeout = 10 * time.Second
 )
 
 // ReconnectConfig holds reconnection settings.
@@ -46,6 +62,7 @@ func DefaultReconnectConfig() ReconnectConfig {
 type WSConfig struct {
 	BaseURL      string          // WebSocket base URL (default: production)
 	Testnet      bool            // Use testnet URLs
+	Gate         *ratelimit.Gate // Backoff gate checked before each dial (default: a new gate)
 	PingInterval time.Duration   // Ping interval (default: 20s)
 	Reconnect    ReconnectConfig // Reconnection settings
 }
@@ -66,8 +83,21 @@ type WSClientCallbacks struct {
 	OnTrade      func(trade *domain.Trade)
 	OnKline      func(kline *domain.Kline)
 	OnOrder      func(order *domain.Order)
+	OnBalance    func(balance *domain.Balance)
 	OnConnect    func()
 	OnDisconnect func(err error)
+
+	// OnDepthUpdate is called with raw diff-depth events for local book maintenance
+	OnDepthUpdate func(update *WSDepthUpdate)
+
+	// OnBalanceDelta is called for balanceUpdate events (deposits, withdrawals, transfers)
+	OnBalanceDelta func(asset string, delta domain.Decimal, at time.Time)
+
+	// OnListenKeyExpired is called when the user data stream reports an expired listenKey
+	OnListenKeyExpired func(listenKey string)
+
+	// OnError is called for failures of background subscription requests
+	OnError func(err error)
 }
 
 // WSClient implements a WebSocket client with automatic reconnection.
@@ -89,6 +119,12 @@ type WSClient struct {
 	reconnectAttempt int
 	reconnectMu      sync.Mutex
 
+	// Method requests awaiting a response, keyed by request ID
+	reqID     atomic.Int64
+	pending   map[int64]chan *WSMethodResponse
+	pendingMu sync.Mutex
+	limiter   *rate.Limiter // Outbound messages, including pings
+
 	// Context for cancellation
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -106,11 +142,16 @@ func NewWSClient(cfg WSConfig) *WSClient {
 	if cfg.Reconnect.InitialDelay == 0 {
 		cfg.Reconnect = DefaultReconnectConfig()
 	}
+	if cfg.Gate == nil {
+		cfg.Gate = ratelimit.NewGate()
+	}
 
 	return &WSClient{
 		config:        cfg,
 		testnet:       cfg.Testnet,
 		subscriptions: NewSubscriptionManager(),
+		pending:       make(map[int64]chan *WSMethodResponse),
+		limiter:       rate.NewLimiter(rate.Limit(wsMaxMessagesPerSecond), 1),
 	}
 }
 
@@ -124,6 +165,12 @@ func (c *WSClient) OnOrderBook(fn func(orderBook *domain.OrderBook)) {
 	c.callbacks.OnOrderBook = fn
 }
 
+// OnDepthUpdate sets the diff-depth callback.
+// Diffs are not full books; they must be applied to a synced local book.
+func (c *WSClient) OnDepthUpdate(fn func(update *WSDepthUpdate)) {
+	c.callbacks.OnDepthUpdate = fn
+}
+
 // OnTrade sets the trade callback.
 func (c *WSClient) OnTrade(fn func(trade *domain.Trade)) {
 	c.callbacks.OnTrade = fn
@@ -139,6 +186,21 @@ func (c *WSClient) OnOrder(fn func(order *domain.Order)) {
 	c.callbacks.OnOrder = fn
 }
 
+// OnBalance sets the balance callback (outboundAccountPosition).
+func (c *WSClient) OnBalance(fn func(balance *domain.Balance)) {
+	c.callbacks.OnBalance = fn
+}
+
+// OnBalanceDelta sets the balance delta callback (balanceUpdate).
+func (c *WSClient) OnBalanceDelta(fn func(asset string, delta domain.Decimal, at time.Time)) {
+	c.callbacks.OnBalanceDelta = fn
+}
+
+// OnListenKeyExpired sets the listenKey expiry callback.
+func (c *WSClient) OnListenKeyExpired(fn func(listenKey string)) {
+	c.callbacks.OnListenKeyExpired = fn
+}
+
 // OnConnect sets the connect callback.
 func (c *WSClient) OnConnect(fn func()) {
 	c.callbacks.OnConnect = fn
@@ -149,6 +211,11 @@ func (c *WSClient) OnDisconnect(fn func(err error)) {
 	c.callbacks.OnDisconnect = fn
 }
 
+// OnError sets the callback for background subscription failures.
+func (c *WSClient) OnError(fn func(err error)) {
+	c.callbacks.OnError = fn
+}
+
 // wsBaseURL returns the WebSocket base URL based on testnet flag.
 func (c *WSClient) wsBaseURL() string {
 	if c.testnet {
@@ -169,6 +236,11 @@ func (c *WSClient) Connect() error {
 	}
 	defer c.connecting.Store(false)
 
+	// Dialing during a rate limit backoff or IP ban would extend it
+	if err := c.config.Gate.Allow(); err != nil {
+		return err
+	}
+
 	c.ctx, c.cancel = context.WithCancel(context.Background())
 
 	return c.dial()
@@ -180,18 +252,12 @@ func (c *WSClient) dial() error {
 	defer c.connMu.Unlock()
 
 	// Build stream URL from subscriptions
+	// Without subscriptions, connect to the bare combined endpoint so streams
+	// added later with SUBSCRIBE arrive in the combined format.
 	streams := c.subscriptions.Streams()
-	var url string
+	url := c.wsBaseURL()
 	if len(streams) > 0 {
-		combinedStream := CombineStreams(streams)
-		url = c.wsBaseURL() + wsCombinedPath + combinedStream
-	} else {
-		// No subscriptions - connect to base URL (will be idle)
-		if c.testnet {
-			url = TestnetWebSocketURL
-		} else {
-			url = BaseWebSocketURL
-		}
+		url += wsCombinedPath + CombineStreams(streams)
 	}
 
 	// Create client option
@@ -226,6 +292,11 @@ func (c *WSClient) dial() error {
 		}
 	})
 
+	// Streams added while the URL was being built are not on this connection
+	if missing := c.subscriptions.Missing(streams); len(missing) > 0 {
+		go c.subscribeMissing(missing)
+	}
+
 	return nil
 }
 
@@ -240,6 +311,7 @@ func (c *WSClient) Disconnect() error {
 
 	c.stopPingTicker()
 	c.connected.Store(false)
+	c.failPending()
 
 	// Send close frame and close connection
 	c.conn.WriteClose(1000, nil)
@@ -277,30 +349,197 @@ func (c *WSClient) IsConnected() bool {
 	return c.connected.Load()
 }
 
-// Subscribe adds a stream subscription.
-// NOTE: Binance doesn't support dynamic subscribe on existing connection.
-// To add new streams, the connection must be reconnected.
-// Returns error if stream is already subscribed.
-func (c *WSClient) Subscribe(stream string) error {
-	if !c.subscriptions.Subscribe(stream) {
-		return nil // Already subscribed
+// Subscribe adds stream subscriptions.
+// When connected, a SUBSCRIBE request is sent on the live connection and
+// Subscribe waits for its ack; other streams are not interrupted.
+// When not connected, the streams are included on the next connect.
+// Streams rejected by the server are not kept.
+//
+// IMPORTANT: Acks are delivered by the read loop, so Subscribe, Unsubscribe and
+// ListSubscriptions must not be called from a stream callback.
+func (c *WSClient) Subscribe(streams ...string) error {
+	added := make([]string, 0, len(streams))
+	for _, stream := range streams {
+		if c.subscriptions.Subscribe(stream) {
+			added = append(added, normalizeStream(stream))
+		}
+	}
+	if len(added) == 0 || !c.connected.Load() {
+		return nil
+	}
+
+	if err := c.callMethod(methodSubscribe, added); err != nil {
+		var exErr *errors.ExchangeError
+		if errors.As(err, &exErr) {
+			for _, stream := range added {
+				c.subscriptions.Unsubscribe(stream)
+			}
+		}
+		return err
 	}
+	return nil
+}
 
-	// If connected, need to reconnect to add new stream
-	if c.connected.Load() {
-		return c.reconnect()
+// Unsubscribe removes stream subscriptions.
+// When connected, an UNSUBSCRIBE request is sent on the live connection and
+// Unsubscribe waits for its ack.
+// Streams the server fails to remove are kept.
+func (c *WSClient) Unsubscribe(streams ...string) error {
+	removed := make([]string, 0, len(streams))
+	for _, stream := range streams {
+		if c.subscriptions.Unsubscribe(stream) {
+			removed = append(removed, normalizeStream(stream))
+		}
+	}
+	if len(removed) == 0 || !c.connected.Load() {
+		return nil
 	}
 
+	if err := c.callMethod(methodUnsubscribe, removed); err != nil {
+		var exErr *errors.ExchangeError
+		if errors.As(err, &exErr) {
+			for _, stream := range removed {
+				c.subscriptions.Subscribe(stream)
+			}
+		}
+		return err
+	}
 	return nil
 }
 
-// Unsubscribe removes a stream subscription.
-// NOTE: Requires reconnection to take effect.
-func (c *WSClient) Unsubscribe(stream string) error {
-	c.subscriptions.Unsubscribe(stream)
+// ListSubscriptions returns the streams the server has active on this connection.
+func (c *WSClient) ListSubscriptions() ([]string, error) {
+	resp, err := c.call(methodListSubscriptions, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var streams []string
+	if err := json.Unmarshal(resp.Result, &streams); err != nil {
+		return nil, fmt.Errorf("parse subscriptions: %w", err)
+	}
+	return streams, nil
+}
+
+// subscribeMissing subscribes streams added while a connection was being dialed.
+func (c *WSClient) subscribeMissing(streams []string) {
+	if err := c.callMethod(methodSubscribe, streams); err != nil {
+		c.reportError(err)
+	}
+}
+
+// callMethod sends a method request and converts a server error into an ExchangeError.
+func (c *WSClient) callMethod(method string, params []string) error {
+	resp, err := c.call(method, params)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return errors.NewExchangeError(exchange, method,
+			fmt.Sprintf("request %d failed: code=%d msg=%s", resp.ID, resp.Error.Code, resp.Error.Msg), nil)
+	}
 	return nil
 }
 
+// call sends a method request and waits for the response with the same ID.
+// Requests are paced to the outbound message limit.
+// A response carrying an error is returned as-is; only transport failures
+// and timeouts return an error.
+func (c *WSClient) call(method string, params []string) (*WSMethodResponse, error) {
+	parent := c.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, wsRequestTimeout)
+	defer cancel()
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, errors.NewConnectionError(exchange, c.wsBaseURL(), method+" not sent: "+err.Error(), true)
+	}
+
+	id := c.reqID.Add(1)
+	ch := make(chan *WSMethodResponse, 1)
+
+	c.pendingMu.Lock()
+	c.pending[id] = ch
+	c.pendingMu.Unlock()
+
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
+
+	if err := c.write(WSMethodRequest{Method: method, Params: params, ID: id}); err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, errors.NewConnectionError(exchange, c.wsBaseURL(), "connection closed before "+method+" response", true)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, errors.NewConnectionError(exchange, c.wsBaseURL(), method+" response timed out", true)
+	}
+}
+
+// write marshals and sends a request on the current connection.
+func (c *WSClient) write(req any) error {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	if conn == nil || !c.connected.Load() {
+		return errors.NewConnectionError(exchange, c.wsBaseURL(), "not connected", true)
+	}
+
+	if err := conn.WriteMessage(gws.OpcodeText, payload); err != nil {
+		return errors.NewConnectionError(exchange, c.wsBaseURL(), err.Error(), true)
+	}
+	return nil
+}
+
+// handleMethodResponse delivers a method response to its waiting request.
+func (c *WSClient) handleMethodResponse(resp *WSMethodResponse) {
+	c.pendingMu.Lock()
+	ch, ok := c.pending[resp.ID]
+	delete(c.pending, resp.ID)
+	c.pendingMu.Unlock()
+
+	if ok {
+		ch <- resp
+	}
+}
+
+// failPending fails every request awaiting a response.
+// Called when the connection closes, since responses can no longer arrive.
+func (c *WSClient) failPending() {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+}
+
+// reportError forwards a background error to the error callback.
+func (c *WSClient) reportError(err error) {
+	if c.callbacks.OnError == nil {
+		return
+	}
+	c.safeCallback(func() {
+		c.callbacks.OnError(err)
+	})
+}
+
 // OnOpen implements gws.EventHandler - called when connection is established.
 func (c *WSClient) OnOpen(socket *gws.Conn) {
 	// Connection is now open
@@ -311,6 +550,7 @@ func (c *WSClient) OnOpen(socket *gws.Conn) {
 func (c *WSClient) OnClose(socket *gws.Conn, err error) {
 	c.connected.Store(false)
 	c.stopPingTicker()
+	c.failPending()
 
 	// Notify disconnect callback
 	c.safeCallback(func() {
@@ -357,6 +597,15 @@ func (c *WSClient) OnMessage(socket *gws.Conn, message *gws.Message) {
 		return
 	}
 
+	// Method responses are not wrapped in a stream envelope
+	if wsMsg.Stream == "" {
+		var resp WSMethodResponse
+		if err := json.Unmarshal(data, &resp); err == nil && resp.ID != 0 {
+			c.handleMethodResponse(&resp)
+			return
+		}
+	}
+
 	// Route based on stream name
 	c.routeMessage(wsMsg.Stream, wsMsg.Data)
 }
@@ -370,8 +619,10 @@ func (c *WSClient) routeMessage(stream string, data []byte) {
 		c.handleTicker(data)
 	case "bookTicker":
 		c.handleBookTicker(data)
-	case "depth", "depth10", "depth20":
-		c.handleDepth(data)
+	case "depth":
+		c.handleDepthUpdate(data)
+	case "depth5", "depth10", "depth20":
+		c.handlePartialDepth(stream, data)
 	case "trade":
 		c.handleTrade(data)
 	case "aggTrade":
@@ -387,8 +638,12 @@ func (c *WSClient) routeMessage(stream string, data []byte) {
 			switch eventType.EventType {
 			case "executionReport":
 				c.handleOrderUpdate(data)
+			case "listenKeyExpired":
+				c.handleListenKeyExpired(data)
 			case "balanceUpdate":
-				// Ignore for now
+				c.handleBalanceUpdate(data)
+			case "outboundAccountPosition":
+				c.handleAccountPosition(data)
 			}
 		}
 	}
@@ -407,10 +662,12 @@ func (c *WSClient) routeDirectMessage(data []byte) {
 	switch event.EventType {
 	case "executionReport":
 		c.handleOrderUpdate(data)
+	case "listenKeyExpired":
+		c.handleListenKeyExpired(data)
 	case "balanceUpdate":
-		// Ignore for now
+		c.handleBalanceUpdate(data)
 	case "outboundAccountPosition":
-		// Account update - ignore for now
+		c.handleAccountPosition(data)
 	}
 }
 
@@ -456,43 +713,43 @@ func (c *WSClient) handleBookTicker(data []byte) {
 	})
 }
 
-// handleDepth handles depth messages.
-func (c *WSClient) handleDepth(data []byte) {
-	if c.callbacks.OnOrderBook == nil {
+// handleDepthUpdate handles diff-depth messages.
+func (c *WSClient) handleDepthUpdate(data []byte) {
+	if c.callbacks.OnDepthUpdate == nil {
 		return
 	}
 
-	// Try as depth update first
-	var depthUpdate WSDepthUpdate
-	if err := json.Unmarshal(data, &depthUpdate); err == nil && depthUpdate.EventType == "depthUpdate" {
-		bids, asks, err := depthUpdate.ToDomain()
-		if err != nil {
-			return
-		}
+	var update WSDepthUpdate
+	if err := json.Unmarshal(data, &update); err != nil || update.EventType != "depthUpdate" {
+		return
+	}
 
-		orderBook := &domain.OrderBook{
-			Exchange:     exchange,
-			Symbol:       domain.NormalizeSymbol(depthUpdate.Symbol),
-			Bids:         bids,
-			Asks:         asks,
-			LastUpdateID: depthUpdate.FinalUpdateID,
-			Timestamp:    time.UnixMilli(depthUpdate.EventTime),
-		}
+	c.safeCallback(func() {
+		c.callbacks.OnDepthUpdate(&update)
+	})
+}
 
-		c.safeCallback(func() {
-			c.callbacks.OnOrderBook(orderBook)
-		})
+// handlePartialDepth handles partial book depth messages.
+// These carry the full top levels, so they are forwarded as complete books.
+// The payload has no symbol, so it is taken from the stream name.
+func (c *WSClient) handlePartialDepth(stream string, data []byte) {
+	if c.callbacks.OnOrderBook == nil {
+		return
+	}
+
+	var snapshot WSDepthSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
 		return
 	}
 
-	// Try as depth snapshot
-	var depthSnapshot WSDepthSnapshot
-	if err := json.Unmarshal(data, &depthSnapshot); err != nil {
+	orderBook, err := snapshot.ToDomain(exchange, ParseStreamSymbol(stream))
+	if err != nil {
 		return
 	}
 
-	// Need to get symbol from context - this shouldn't happen normally
-	// as snapshots come via REST API, but handle gracefully
+	c.safeCallback(func() {
+		c.callbacks.OnOrderBook(orderBook)
+	})
 }
 
 // handleTrade handles trade messages.
@@ -579,6 +836,66 @@ func (c *WSClient) handleOrderUpdate(data []byte) {
 	})
 }
 
+// handleBalanceUpdate handles balanceUpdate messages.
+func (c *WSClient) handleBalanceUpdate(data []byte) {
+	if c.callbacks.OnBalanceDelta == nil {
+		return
+	}
+
+	var update WSBalanceUpdate
+	if err := json.Unmarshal(data, &update); err != nil {
+		return
+	}
+
+	delta, err := update.Delta()
+	if err != nil {
+		return
+	}
+
+	c.safeCallback(func() {
+		c.callbacks.OnBalanceDelta(update.Asset, delta, time.UnixMilli(update.ClearTime))
+	})
+}
+
+// handleAccountPosition handles outboundAccountPosition messages.
+func (c *WSClient) handleAccountPosition(data []byte) {
+	if c.callbacks.OnBalance == nil {
+		return
+	}
+
+	var position WSAccountPosition
+	if err := json.Unmarshal(data, &position); err != nil {
+		return
+	}
+
+	balances, err := position.ToDomain(exchange)
+	if err != nil {
+		return
+	}
+
+	for _, balance := range balances {
+		c.safeCallback(func() {
+			c.callbacks.OnBalance(balance)
+		})
+	}
+}
+
+// handleListenKeyExpired handles listenKey expiry events.
+func (c *WSClient) handleListenKeyExpired(data []byte) {
+	if c.callbacks.OnListenKeyExpired == nil {
+		return
+	}
+
+	var event WSListenKeyExpired
+	if err := json.Unmarshal(data, &event); err != nil {
+		return
+	}
+
+	c.safeCallback(func() {
+		c.callbacks.OnListenKeyExpired(event.ListenKey)
+	})
+}
+
 // safeCallback executes a callback with panic recovery.
 // CRITICAL: Callbacks MUST be wrapped in panic recovery.
 func (c *WSClient) safeCallback(fn func()) {
@@ -608,7 +925,9 @@ func (c *WSClient) startPingTicker() {
 			conn := c.conn
 			c.connMu.RUnlock()
 
-			if conn != nil && c.connected.Load() {
+			// Pings count towards the outbound message limit; when the budget
+			// is spent by method requests the ping is skipped until the next tick
+			if conn != nil && c.connected.Load() && c.limiter.Allow() {
 				conn.WritePing(nil)
 			}
 		}
@@ -671,6 +990,11 @@ func (c *WSClient) reconnect() error {
 		delay := c.calculateBackoff(attempt)
 		time.Sleep(delay)
 
+		// Hold reconnects until any rate limit backoff or IP ban lifts
+		if err := c.config.Gate.Wait(c.ctx); err != nil {
+			continue
+		}
+
 		// Attempt to connect
 		if err := c.dial(); err != nil {
 			// Continue trying
@@ -686,11 +1010,14 @@ func (c *WSClient) reconnect() error {
 	}
 }
 
-// calculateBackoff calculates the reconnection delay with exponential backoff and jitter.
-// Formula: delay = min(initialDelay * 2^attempt, maxDelay) * (1 + random * jitter)
+// calculateBackoff calculates the reconnection delay for an attempt.
 func (c *WSClient) calculateBackoff(attempt int) time.Duration {
-	cfg := c.config.Reconnect
+	return reconnectDelay(c.config.Reconnect, attempt)
+}
 
+// reconnectDelay calculates the reconnection delay with exponential backoff and jitter.
+// Formula: delay = min(initialDelay * 2^attempt, maxDelay) * (1 + random * jitter)
+func reconnectDelay(cfg ReconnectConfig, attempt int) time.Duration {
 	// Calculate exponential delay
 	delay := cfg.InitialDelay
 	for i := 1; i < attempt; i++ {
@@ -20,6 +20,76 @@ type WSMessage struct {
 	Data   json.RawMessage `json:"data"`
 }
 
+// WSMethodRequest is a live subscription management request.
+// Example: {"method":"SUBSCRIBE","params":["btcusdt@depth"],"id":1}
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#live-subscribing-unsubscribing-to-streams
+type WSMethodRequest struct {
+	Method string   `json:"method"`           // SUBSCRIBE, UNSUBSCRIBE or LIST_SUBSCRIPTIONS
+	Params []string `json:"params,omitempty"` // Stream names
+	ID     int64    `json:"id"`               // Request ID, echoed in the response
+}
+
+// WSMethodResponse is the response to a WSMethodRequest.
+// Success: {"result":null,"id":1}
+// Failure: {"error":{"code":2,"msg":"Invalid request: unknown variant..."},"id":1}
+type WSMethodResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *WSMethodError  `json:"error,omitempty"`
+	ID     int64           `json:"id"`
+}
+
+// WSMethodError is the error payload of a failed WSMethodResponse.
+type WSMethodError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// WSAPIRequest is a WebSocket API request.
+// Example: {"id":1,"method":"order.status","params":{"symbol":"BTCUSDT","orderId":12345}}
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/general-api-information
+type WSAPIRequest struct {
+	ID     int64          `json:"id"`               // Request ID, echoed in the response
+	Method string         `json:"method"`           // e.g., order.place, session.logon
+	Params map[string]any `json:"params,omitempty"` // Method parameters
+}
+
+// WSAPIResponse is the response to a WSAPIRequest.
+// Success: {"id":1,"status":200,"result":{...},"rateLimits":[...]}
+// Failure: {"id":1,"status":400,"error":{"code":-2011,"msg":"Unknown order sent."},"rateLimits":[...]}
+type WSAPIResponse struct {
+	ID         int64            `json:"id"`
+	Status     int              `json:"status"` // HTTP-like status code
+	Result     json.RawMessage  `json:"result"`
+	Error      *WSAPIError      `json:"error,omitempty"`
+	RateLimits []WSAPIRateLimit `json:"rateLimits"`
+}
+
+// WSAPIError is the error payload of a failed WSAPIResponse.
+// Rate limit errors (429, 418) carry the time the limit lifts in data.retryAfter.
+type WSAPIError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+	Data *struct {
+		ServerTime int64 `json:"serverTime"`
+		RetryAfter int64 `json:"retryAfter"` // Unix milliseconds
+	} `json:"data,omitempty"`
+}
+
+// WSAPIRateLimit is a rate limit bucket with its current usage.
+// Example: {"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":6000,"count":12}
+type WSAPIRateLimit struct {
+	RateLimit
+	Count int `json:"count"`
+}
+
+// WSAPIEvent is a user data stream event delivered on the WebSocket API.
+// Example: {"subscriptionId":0,"event":{"e":"executionReport",...}}
+// Documentation: https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api/user-data-stream-requests
+type WSAPIEvent struct {
+	SubscriptionID *int            `json:"subscriptionId"`
+	Event          json.RawMessage `json:"event"`
+}
+
 // WSTicker represents a ticker update from the ticker stream.
 // WebSocket Stream: <symbol>@ticker or <symbol>@ticker@1s
 // Documentation: https://binance-docs.github.io/apidocs/spot/en/#individual-symbol-ticker-streams
@@ -53,7 +123,7 @@ type WSTicker struct {
 
 // ToDomain converts WSTicker to domain.Ticker.
 func (t *WSTicker) ToDomain(exchange string) (*domain.Ticker, error) {
-	symbol := domain.NormalizeSymbol(t.Symbol)
+	symbol := symbols.Normalize(t.Symbol)
 
 	bidPrice, err := domain.NewDecimal(t.BidPrice)
 	if err != nil {
@@ -148,7 +218,7 @@ type WSBookTicker struct {
 
 // ToDomain converts WSBookTicker to domain.Ticker (limited fields).
 func (t *WSBookTicker) ToDomain(exchange string) (*domain.Ticker, error) {
-	symbol := domain.NormalizeSymbol(t.Symbol)
+	symbol := symbols.Normalize(t.Symbol)
 
 	bidPrice, err := domain.NewDecimal(t.BidPrice)
 	if err != nil {
@@ -233,8 +303,9 @@ func (d *WSDepthUpdate) ToDomain() (bids, asks []domain.OrderBookLevel, err erro
 }
 
 // WSDepthSnapshot represents a full order book snapshot.
-// This is the initial snapshot received when subscribing to depth streams.
-// Documentation: https://binance-docs.github.io/apidocs/spot/en/#diff-depth-stream
+// The same shape is returned by GET /api/v3/depth and the partial book depth
+// streams (<symbol>@depth<levels>).
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#partial-book-depth-streams
 type WSDepthSnapshot struct {
 	LastUpdateID int64      `json:"lastUpdateId"` // Last update ID
 	Bids         [][]string `json:"bids"`         // Bids [[price, qty], ...]
@@ -243,7 +314,7 @@ type WSDepthSnapshot struct {
 
 // ToDomain converts WSDepthSnapshot to domain.OrderBook.
 func (d *WSDepthSnapshot) ToDomain(exchange, symbol string) (*domain.OrderBook, error) {
-	normalizedSymbol := domain.NormalizeSymbol(symbol)
+	normalizedSymbol := symbols.Normalize(symbol)
 
 	bids := make([]domain.OrderBookLevel, 0, len(d.Bids))
 	for _, bid := range d.Bids {
@@ -307,7 +378,7 @@ type WSTrade struct {
 
 // ToDomain converts WSTrade to domain.Trade.
 func (t *WSTrade) ToDomain(exchange string) (*domain.Trade, error) {
-	symbol := domain.NormalizeSymbol(t.Symbol)
+	symbol := symbols.Normalize(t.Symbol)
 
 	price, err := domain.NewDecimal(t.Price)
 	if err != nil {
@@ -378,50 +449,14 @@ type WSOrderUpdate struct {
 
 // ToDomain converts WSOrderUpdate to domain.Order.
 func (o *WSOrderUpdate) ToDomain(exchange string) (*domain.Order, error) {
-	symbol := domain.NormalizeSymbol(o.Symbol)
-
-	// Parse side
-	var side domain.OrderSide
-	switch o.Side {
-	case "BUY":
-		side = domain.OrderSideBuy
-	case "SELL":
-		side = domain.OrderSideSell
-	default:
-		return nil, fmt.Errorf("invalid order side: %s", o.Side)
-	}
-
-	// Parse type
-	var orderType domain.OrderType
-	switch o.OrderType {
-	case "LIMIT":
-		orderType = domain.OrderTypeLimit
-	case "MARKET":
-		orderType = domain.OrderTypeMarket
-	default:
-		orderType = domain.OrderType(o.OrderType)
-	}
+	symbol := symbols.Normalize(o.Symbol)
 
-	// Parse status
-	var status domain.OrderStatus
-	switch o.OrderStatus {
-	case "NEW":
-		status = domain.OrderStatusNew
-	case "PARTIALLY_FILLED":
-		status = domain.OrderStatusPartiallyFilled
-	case "FILLED":
-		status = domain.OrderStatusFilled
-	case "CANCELED":
-		status = domain.OrderStatusCanceled
-	case "PENDING_CANCEL":
-		status = domain.OrderStatusCanceling
-	case "REJECTED":
-		status = domain.OrderStatusRejected
-	case "EXPIRED":
-		status = domain.OrderStatusExpired
-	default:
-		status = domain.OrderStatus(o.OrderStatus)
+	side, err := parseOrderSide(o.Side)
+	if err != nil {
+		return nil, err
 	}
+	orderType := parseOrderType(o.OrderType)
+	status := parseOrderStatus(o.OrderStatus)
 
 	// Parse decimals
 	price, _ := domain.NewDecimal(o.OriginalPrice)
@@ -449,7 +484,7 @@ func (o *WSOrderUpdate) ToDomain(exchange string) (*domain.Order, error) {
 		commission = domain.Zero()
 	}
 
-	return &domain.Order{
+	order := &domain.Order{
 		Exchange:        exchange,
 		Symbol:          symbol,
 		ID:              fmt.Sprintf("%d", o.OrderID),
@@ -461,13 +496,86 @@ func (o *WSOrderUpdate) ToDomain(exchange string) (*domain.Order, error) {
 		Quantity:        qty,
 		FilledQuantity:  filledQty,
 		QuoteQuantity:   quoteQty,
-		Commission:      commission,
+		Commission:      domain.Zero(), // Per fill only; see Fills
 		CommissionAsset: o.CommissionAsset,
 		CreatedAt:       time.UnixMilli(o.OrderCreationTime),
 		UpdatedAt:       time.UnixMilli(o.EventTime),
 		TradeID:         fmt.Sprintf("%d", o.TradeID),
 		IsWorking:       o.WorkingTime > 0 && !status.IsFinal(),
-	}, nil
+	}
+
+	// A trade execution reports the single fill that caused it
+	lastQty, _ := domain.NewDecimal(o.LastFilledQuantity)
+	if o.TradeID >= 0 && lastQty != nil && !domain.IsZero(lastQty) {
+		lastPrice, _ := domain.NewDecimal(o.LastFilledPrice)
+		lastQuote, _ := domain.NewDecimal(o.LastQuoteQty)
+		if lastQuote == nil && lastPrice != nil {
+			lastQuote = domain.Mul(lastPrice, lastQty)
+		}
+		order.Fills = []domain.Trade{{
+			Exchange:        exchange,
+			Symbol:          symbol,
+			ID:              order.TradeID,
+			OrderID:         order.ID,
+			Price:           lastPrice,
+			Quantity:        lastQty,
+			QuoteQuantity:   lastQuote,
+			Commission:      commission,
+			CommissionAsset: o.CommissionAsset,
+			Side:            side,
+			IsMaker:         o.IsMaker,
+			Timestamp:       time.UnixMilli(o.TradeTime),
+		}}
+	}
+
+	return order, nil
+}
+
+// parseOrderSide converts a Binance side to domain.OrderSide.
+func parseOrderSide(side string) (domain.OrderSide, error) {
+	switch side {
+	case "BUY":
+		return domain.OrderSideBuy, nil
+	case "SELL":
+		return domain.OrderSideSell, nil
+	default:
+		return "", fmt.Errorf("invalid order side: %s", side)
+	}
+}
+
+// parseOrderType converts a Binance order type to domain.OrderType.
+// Types without a domain constant (e.g., LIMIT_MAKER) are passed through.
+func parseOrderType(orderType string) domain.OrderType {
+	switch orderType {
+	case "LIMIT":
+		return domain.OrderTypeLimit
+	case "MARKET":
+		return domain.OrderTypeMarket
+	default:
+		return domain.OrderType(orderType)
+	}
+}
+
+// parseOrderStatus converts a Binance order status to domain.OrderStatus.
+func parseOrderStatus(status string) domain.OrderStatus {
+	switch status {
+	case "NEW":
+		return domain.OrderStatusNew
+	case "PARTIALLY_FILLED":
+		return domain.OrderStatusPartiallyFilled
+	case "FILLED":
+		return domain.OrderStatusFilled
+	case "CANCELED":
+		return domain.OrderStatusCanceled
+	case "PENDING_CANCEL":
+		return domain.OrderStatusCanceling
+	case "REJECTED":
+		return domain.OrderStatusRejected
+	case "EXPIRED", "EXPIRED_IN_MATCH":
+		return domain.OrderStatusExpired
+	default:
+		return domain.OrderStatus(status)
+	}
 }
 
 // WSBalanceUpdate represents a balance update from the user data stream.
@@ -480,6 +588,62 @@ type WSBalanceUpdate struct {
 	ClearTime    int64  `json:"T"` // Clear time
 }
 
+// Delta returns the parsed balance delta.
+func (b *WSBalanceUpdate) Delta() (domain.Decimal, error) {
+	delta, err := domain.NewDecimal(b.BalanceDelta)
+	if err != nil {
+		return nil, fmt.Errorf("parse balance_delta: %w", err)
+	}
+	return delta, nil
+}
+
+// WSAccountPosition is sent whenever account balances change.
+// It contains the full state of every asset that changed.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#payload-account-update
+type WSAccountPosition struct {
+	EventType      string `json:"e"` // Event type (outboundAccountPosition)
+	EventTime      int64  `json:"E"` // Event time
+	LastUpdateTime int64  `json:"u"` // Time of last account update
+	Balances       []struct {
+		Asset  string `json:"a"` // Asset
+		Free   string `json:"f"` // Free
+		Locked string `json:"l"` // Locked
+	} `json:"B"`
+}
+
+// ToDomain converts WSAccountPosition to domain balances.
+func (p *WSAccountPosition) ToDomain(exchange string) ([]*domain.Balance, error) {
+	updated := time.UnixMilli(p.LastUpdateTime)
+	balances := make([]*domain.Balance, 0, len(p.Balances))
+	for _, b := range p.Balances {
+		free, err := domain.NewDecimal(b.Free)
+		if err != nil {
+			return nil, fmt.Errorf("parse free: %w", err)
+		}
+		locked, err := domain.NewDecimal(b.Locked)
+		if err != nil {
+			return nil, fmt.Errorf("parse locked: %w", err)
+		}
+		balances = append(balances, &domain.Balance{
+			Exchange:  exchange,
+			Asset:     b.Asset,
+			Free:      free,
+			Locked:    locked,
+			Timestamp: updated,
+		})
+	}
+	return balances, nil
+}
+
+// WSListenKeyExpired is sent on the user data stream when its listenKey expires.
+// The stream stops delivering events until a new listenKey is used.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#listen-key-expired
+type WSListenKeyExpired struct {
+	EventType string `json:"e"`         // Event type (listenKeyExpired)
+	EventTime int64  `json:"E"`         // Event time
+	ListenKey string `json:"listenKey"` // Expired listenKey
+}
+
 // WSKline represents a kline/candlestick update.
 // WebSocket Stream: <symbol>@kline_<interval>
 // Documentation: https://binance-docs.github.io/apidocs/spot/en/#kline-candlestick-streams
@@ -510,7 +674,7 @@ type WSKline struct {
 
 // ToDomain converts WSKline to domain.Kline.
 func (k *WSKline) ToDomain(exchange string) (*domain.Kline, error) {
-	symbol := domain.NormalizeSymbol(k.Symbol)
+	symbol := symbols.Normalize(k.Symbol)
 
 	open, err := domain.NewDecimal(k.Kline.OpenPrice)
 	if err != nil {
@@ -589,7 +753,7 @@ type WSAggTrade struct {
 
 // ToDomain converts WSAggTrade to domain.Trade.
 func (t *WSAggTrade) ToDomain(exchange string) (*domain.Trade, error) {
-	symbol := domain.NormalizeSymbol(t.Symbol)
+	symbol := symbols.Normalize(t.Symbol)
 
 	price, err := domain.NewDecimal(t.Price)
 	if err != nil {
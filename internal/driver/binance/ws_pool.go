@@ -0,0 +1,328 @@
+package binance
+
+import (
+	"sync"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// MaxStreamsPerConnection is the Binance limit on streams per WebSocket connection.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#websocket-limits
+const MaxStreamsPerConnection = 1024
+
+// WSPool spreads market streams across multiple WebSocket connections.
+// Each connection (shard) carries at most maxStreams streams and reconnects
+// independently; the pool presents a single subscribe/callback surface.
+//
+// Streams are placed on the first shard with room. A new shard is created and,
+// if the pool is connected, dialed when all shards are full. Shards are kept
+// once created, even when their streams are unsubscribed.
+type WSPool struct {
+	config     WSConfig
+	maxStreams int
+
+	callbacks    WSClientCallbacks
+	onDisconnect func(streams []string, err error)
+
+	shards    []*WSClient
+	owner     map[string]*WSClient // Stream -> shard
+	load      map[*WSClient]int    // Streams assigned per shard
+	connected bool                 // Connect called and Close not yet called
+	mu        sync.Mutex
+}
+
+// NewWSPool creates a pool with at most maxStreams streams per connection.
+// maxStreams <= 0 or above MaxStreamsPerConnection uses MaxStreamsPerConnection.
+func NewWSPool(cfg WSConfig, maxStreams int) *WSPool {
+	if maxStreams <= 0 || maxStreams > MaxStreamsPerConnection {
+		maxStreams = MaxStreamsPerConnection
+	}
+	return &WSPool{
+		config:     cfg,
+		maxStreams: maxStreams,
+		owner:      make(map[string]*WSClient),
+		load:       make(map[*WSClient]int),
+	}
+}
+
+// OnTicker sets the ticker callback.
+func (p *WSPool) OnTicker(fn func(ticker *domain.Ticker)) {
+	p.setCallback(func(cb *WSClientCallbacks) { cb.OnTicker = fn })
+}
+
+// OnOrderBook sets the order book callback (partial book depth streams).
+func (p *WSPool) OnOrderBook(fn func(orderBook *domain.OrderBook)) {
+	p.setCallback(func(cb *WSClientCallbacks) { cb.OnOrderBook = fn })
+}
+
+// OnDepthUpdate sets the diff-depth callback.
+func (p *WSPool) OnDepthUpdate(fn func(update *WSDepthUpdate)) {
+	p.setCallback(func(cb *WSClientCallbacks) { cb.OnDepthUpdate = fn })
+}
+
+// OnTrade sets the trade callback.
+func (p *WSPool) OnTrade(fn func(trade *domain.Trade)) {
+	p.setCallback(func(cb *WSClientCallbacks) { cb.OnTrade = fn })
+}
+
+// OnKline sets the kline callback.
+func (p *WSPool) OnKline(fn func(kline *domain.Kline)) {
+	p.setCallback(func(cb *WSClientCallbacks) { cb.OnKline = fn })
+}
+
+// OnConnect sets the connect callback, called each time a shard connects.
+func (p *WSPool) OnConnect(fn func()) {
+	p.setCallback(func(cb *WSClientCallbacks) { cb.OnConnect = fn })
+}
+
+// OnError sets the callback for background subscription failures.
+func (p *WSPool) OnError(fn func(err error)) {
+	p.setCallback(func(cb *WSClientCallbacks) { cb.OnError = fn })
+}
+
+// OnDisconnect sets the disconnect callback, called each time a shard disconnects.
+// streams are the streams carried by that shard.
+func (p *WSPool) OnDisconnect(fn func(streams []string, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onDisconnect = fn
+	for _, shard := range p.shards {
+		p.applyCallbacks(shard)
+	}
+}
+
+// setCallback updates the pool callbacks and applies them to every shard.
+func (p *WSPool) setCallback(set func(cb *WSClientCallbacks)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	set(&p.callbacks)
+	for _, shard := range p.shards {
+		p.applyCallbacks(shard)
+	}
+}
+
+// applyCallbacks installs the pool callbacks on a shard.
+// Callers must hold p.mu.
+func (p *WSPool) applyCallbacks(shard *WSClient) {
+	shard.OnTicker(p.callbacks.OnTicker)
+	shard.OnOrderBook(p.callbacks.OnOrderBook)
+	shard.OnDepthUpdate(p.callbacks.OnDepthUpdate)
+	shard.OnTrade(p.callbacks.OnTrade)
+	shard.OnKline(p.callbacks.OnKline)
+	shard.OnConnect(p.callbacks.OnConnect)
+	shard.OnError(p.callbacks.OnError)
+
+	onDisconnect := p.onDisconnect
+	shard.OnDisconnect(func(err error) {
+		if onDisconnect != nil {
+			onDisconnect(shard.subscriptions.Streams(), err)
+		}
+	})
+}
+
+// newShard creates a shard with the pool callbacks.
+// Callers must hold p.mu.
+func (p *WSPool) newShard() *WSClient {
+	shard := NewWSClient(p.config)
+	p.applyCallbacks(shard)
+	p.shards = append(p.shards, shard)
+	return shard
+}
+
+// Connect dials every shard, creating the first one if none exist.
+func (p *WSPool) Connect() error {
+	p.mu.Lock()
+	if len(p.shards) == 0 {
+		p.newShard()
+	}
+	p.connected = true
+	shards := append([]*WSClient(nil), p.shards...)
+	p.mu.Unlock()
+
+	for _, shard := range shards {
+		if err := shard.Connect(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes every shard.
+func (p *WSPool) Close() error {
+	p.mu.Lock()
+	p.connected = false
+	shards := append([]*WSClient(nil), p.shards...)
+	p.mu.Unlock()
+
+	var firstErr error
+	for _, shard := range shards {
+		if err := shard.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// IsConnected returns true if every shard is connected.
+func (p *WSPool) IsConnected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.shards) == 0 {
+		return false
+	}
+	for _, shard := range p.shards {
+		if !shard.IsConnected() {
+			return false
+		}
+	}
+	return true
+}
+
+// Subscribe adds streams, placing each on a shard with room.
+// New shards are dialed before their streams are subscribed.
+func (p *WSPool) Subscribe(streams ...string) error {
+	p.mu.Lock()
+	groups := make(map[*WSClient][]string)
+	var dial []*WSClient
+	for _, stream := range streams {
+		stream = normalizeStream(stream)
+		if _, ok := p.owner[stream]; ok {
+			continue
+		}
+
+		shard := p.shardWithRoom()
+		if shard == nil {
+			shard = p.newShard()
+			if p.connected {
+				dial = append(dial, shard)
+			}
+		}
+		p.owner[stream] = shard
+		p.load[shard]++
+		groups[shard] = append(groups[shard], stream)
+	}
+	p.mu.Unlock()
+
+	var firstErr error
+	for _, shard := range dial {
+		// Streams are subscribed below once connected
+		if err := shard.Connect(); err != nil {
+			p.removeShard(shard, groups[shard])
+			delete(groups, shard)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	for shard, group := range groups {
+		if err := shard.Subscribe(group...); err != nil {
+			// Rejected streams are dropped by the shard; others are sent on reconnect
+			var exErr *errors.ExchangeError
+			if errors.As(err, &exErr) {
+				p.release(shard, group)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Unsubscribe removes streams from their shards.
+func (p *WSPool) Unsubscribe(streams ...string) error {
+	p.mu.Lock()
+	groups := make(map[*WSClient][]string)
+	for _, stream := range streams {
+		stream = normalizeStream(stream)
+		if shard, ok := p.owner[stream]; ok {
+			delete(p.owner, stream)
+			p.load[shard]--
+			groups[shard] = append(groups[shard], stream)
+		}
+	}
+	p.mu.Unlock()
+
+	var firstErr error
+	for shard, group := range groups {
+		if err := shard.Unsubscribe(group...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// shardWithRoom returns the first shard with capacity for one more stream.
+// Returns nil if all shards are full.
+// Callers must hold p.mu.
+func (p *WSPool) shardWithRoom() *WSClient {
+	for _, shard := range p.shards {
+		if p.load[shard] < p.maxStreams {
+			return shard
+		}
+	}
+	return nil
+}
+
+// release drops ownership of streams on shard.
+func (p *WSPool) release(shard *WSClient, streams []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, stream := range streams {
+		if p.owner[stream] == shard {
+			delete(p.owner, stream)
+			p.load[shard]--
+		}
+	}
+}
+
+// removeShard drops a shard that failed to dial, along with its streams.
+// A shard that never connected does not reconnect on its own, so it must not
+// be offered to later subscriptions.
+func (p *WSPool) removeShard(shard *WSClient, streams []string) {
+	p.release(shard, streams)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, s := range p.shards {
+		if s == shard {
+			p.shards = append(p.shards[:i], p.shards[i+1:]...)
+			break
+		}
+	}
+	delete(p.load, shard)
+	shard.Close()
+}
+
+// Shards returns the number of connections in the pool.
+func (p *WSPool) Shards() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.shards)
+}
+
+// Streams returns the number of streams across all shards.
+func (p *WSPool) Streams() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.owner)
+}
+
+// ShardStreams returns the stream count of each shard, in creation order.
+func (p *WSPool) ShardStreams() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counts := make([]int, len(p.shards))
+	for i, shard := range p.shards {
+		counts[i] = p.load[shard]
+	}
+	return counts
+}
@@ -0,0 +1,334 @@
+package bybit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/ratelimit"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+func init() {
+	driver.Register(exchange, NewDriver)
+}
+
+// symbols converts between exchange and normalized symbols using the listed
+// base and quote assets. It is filled whenever exchange info is loaded.
+var symbols = domain.Symbols(exchange)
+
+// Driver adapts the Bybit REST and WebSocket clients to driver.Driver.
+// Market data uses the public spot stream. When credentials are configured,
+// order and wallet updates are delivered from the private stream.
+type Driver struct {
+	rest    *RESTClient
+	public  *WSClient
+	private *WSClient // nil without credentials
+
+	onError func(err error)
+
+	// Lifecycle of background loads retried after Connect
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDriver creates a Bybit driver from driver configuration.
+func NewDriver(cfg driver.Config) (driver.Driver, error) {
+	if cfg.KeyType != "" && cfg.KeyType != driver.KeyTypeHMAC {
+		return nil, errors.NewValidationError("key_type", cfg.KeyType, "bybit driver supports HMAC keys only")
+	}
+	if cfg.OrderTransport != "" && cfg.OrderTransport != driver.OrderTransportREST {
+		return nil, errors.NewValidationError("order_transport", cfg.OrderTransport, "bybit driver supports REST order entry only")
+	}
+
+	// One gate per driver: limits and bans apply to the whole IP
+	gate := ratelimit.NewGate()
+
+	rest, err := NewRESTClient(Config{
+		APIKey:     cfg.APIKey,
+		APISecret:  cfg.APISecret,
+		Timeout:    cfg.Timeout,
+		RecvWindow: cfg.RecvWindow,
+		Clock:      cfg.Clock,
+		Testnet:    cfg.Testnet,
+		Gate:       gate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	wsCfg := WSConfig{
+		Testnet:      cfg.Testnet,
+		Gate:         gate,
+		Clock:        rest.Clock(),
+		PingInterval: cfg.PingInterval,
+		Reconnect: ReconnectConfig{
+			InitialDelay: cfg.ReconnectDelay,
+			MaxDelay:     cfg.MaxReconnectWait,
+			MaxAttempts:  0, // Infinite
+			Jitter:       0.1,
+		},
+	}
+
+	d := &Driver{
+		rest:   rest,
+		public: NewWSClient(wsCfg),
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+
+	if cfg.APIKey != "" && cfg.APISecret != "" {
+		privateCfg := wsCfg
+		privateCfg.Private = true
+		privateCfg.APIKey = cfg.APIKey
+		privateCfg.APISecret = cfg.APISecret
+		d.private = NewWSClient(privateCfg)
+		// Sent after auth succeeds
+		d.private.Subscribe(TopicOrder)
+		d.private.Subscribe(TopicWallet)
+	}
+
+	return d, nil
+}
+
+// Name returns the exchange name.
+func (d *Driver) Name() string {
+	return exchange
+}
+
+// Connect establishes the public stream and, if configured, the private stream.
+// Symbols are loaded from the instruments info in the background, retrying
+// with backoff until it succeeds; until then they are normalized
+// heuristically.
+func (d *Driver) Connect(ctx context.Context) error {
+	d.wg.Go(func() {
+		d.retry("exchange_info", d.loadSymbols(d.ctx), d.loadSymbols)
+	})
+
+	if err := d.public.Connect(); err != nil {
+		return err
+	}
+	if d.private != nil {
+		return d.private.Connect()
+	}
+	return nil
+}
+
+// loadSymbols registers the listed symbols from the instruments info.
+func (d *Driver) loadSymbols(ctx context.Context) error {
+	_, err := d.GetExchangeInfo(ctx, driver.ExchangeInfoQuery{})
+	return err
+}
+
+// retry calls fn again until it succeeds or the driver is closed, backing
+// off between attempts. err is the result of the first attempt; failures
+// are reported to the error callback.
+func (d *Driver) retry(operation string, err error, fn func(ctx context.Context) error) {
+	for attempt := 1; err != nil; attempt++ {
+		if d.ctx.Err() != nil {
+			return
+		}
+		if d.onError != nil {
+			d.onError(errors.NewExchangeError(exchange, operation, "failed, retrying", err))
+		}
+
+		timer := time.NewTimer(reconnectDelay(DefaultReconnectConfig(), attempt))
+		select {
+		case <-d.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		err = fn(d.ctx)
+	}
+}
+
+// Close closes the WebSocket and REST clients.
+func (d *Driver) Close() error {
+	d.cancel()
+	d.wg.Wait()
+
+	err := d.public.Close()
+	if d.private != nil {
+		d.private.Close()
+	}
+	d.rest.Close()
+	return err
+}
+
+// IsConnected returns true if the public stream is connected.
+func (d *Driver) IsConnected() bool {
+	return d.public.IsConnected()
+}
+
+// Backoff reports whether traffic is held back after an IP rate limit breach.
+func (d *Driver) Backoff() driver.BackoffStatus {
+	status := d.rest.Gate().Status()
+	return driver.BackoffStatus{
+		Active: status.Closed,
+		Banned: status.Banned,
+		Until:  status.Until,
+		Reason: status.Reason,
+	}
+}
+
+// Ping tests REST connectivity.
+func (d *Driver) Ping(ctx context.Context) error {
+	return d.rest.Ping(ctx)
+}
+
+// GetServerTime returns the server time in milliseconds.
+func (d *Driver) GetServerTime(ctx context.Context) (int64, error) {
+	return d.rest.GetServerTime(ctx)
+}
+
+// GetExchangeInfo returns spot instruments as domain types.
+// Listed symbols are added to the symbol registry.
+func (d *Driver) GetExchangeInfo(ctx context.Context, query driver.ExchangeInfoQuery) (*domain.ExchangeInfo, error) {
+	info, err := d.rest.GetExchangeInfo(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result := info.ToDomain(time.Now())
+	symbols.Register(result.Symbols...)
+	return result, nil
+}
+
+// GetKlines returns historical spot klines, paging through long ranges.
+func (d *Driver) GetKlines(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	return d.rest.GetKlines(ctx, symbol, interval, start, end)
+}
+
+// PlaceOrder submits a new spot order.
+func (d *Driver) PlaceOrder(ctx context.Context, req *domain.OrderRequest) (*domain.Order, error) {
+	return d.rest.PlaceOrder(ctx, req)
+}
+
+// CancelOrder cancels a spot order.
+func (d *Driver) CancelOrder(ctx context.Context, req *domain.CancelRequest) (*domain.Order, error) {
+	return d.rest.CancelOrder(ctx, req)
+}
+
+// CancelAllOrders cancels all open spot orders for a symbol.
+func (d *Driver) CancelAllOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	return d.rest.CancelAllOrders(ctx, symbol)
+}
+
+// GetOrder returns a single order by exchange order ID.
+func (d *Driver) GetOrder(ctx context.Context, symbol, orderID string) (*domain.Order, error) {
+	return d.rest.GetOrder(ctx, symbol, orderID)
+}
+
+// GetOpenOrders returns open spot orders.
+func (d *Driver) GetOpenOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	return d.rest.GetOpenOrders(ctx, symbol)
+}
+
+// GetAllOrders returns spot order history for a symbol.
+func (d *Driver) GetAllOrders(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.Order, error) {
+	return d.rest.GetOrderHistory(ctx, symbol, startTime, endTime, limit)
+}
+
+// GetMyTrades returns spot execution history for a symbol.
+func (d *Driver) GetMyTrades(ctx context.Context, symbol string, since time.Time, limit int) ([]domain.Trade, error) {
+	return d.rest.GetExecutions(ctx, symbol, since, limit)
+}
+
+// GetBalances returns all non-empty balances of the unified account.
+func (d *Driver) GetBalances(ctx context.Context) ([]domain.Balance, error) {
+	account, err := d.rest.GetAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]domain.Balance, 0, len(account.Coins))
+	for i := range account.Coins {
+		balance, err := account.Coins[i].ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		if domain.IsZero(balance.Free) && domain.IsZero(balance.Locked) {
+			continue
+		}
+		balances = append(balances, *balance)
+	}
+	return balances, nil
+}
+
+// SetCallbacks wires stream callbacks into the WebSocket clients.
+// Connection callbacks follow the public stream; user data connection
+// callbacks follow the private stream.
+func (d *Driver) SetCallbacks(cb driver.Callbacks) {
+	d.public.OnTicker(cb.OnTicker)
+	d.public.OnOrderBook(cb.OnOrderBook)
+	d.public.OnTrade(cb.OnTrade)
+	d.public.OnKline(cb.OnKline)
+	d.public.OnConnect(cb.OnConnect)
+	d.public.OnDisconnect(cb.OnDisconnect)
+	d.public.OnError(cb.OnError)
+	d.rest.Gate().OnClose(cb.OnError)
+	d.onError = cb.OnError
+
+	if d.private != nil {
+		d.private.OnOrder(cb.OnOrder)
+		d.private.OnBalance(cb.OnBalance)
+		d.private.OnConnect(cb.OnUserDataConnect)
+		d.private.OnDisconnect(cb.OnUserDataDisconnect)
+		d.private.OnError(cb.OnError)
+	}
+}
+
+// ArmCancelCountdown enables Disconnect Cancel Protection: the exchange
+// cancels all open spot orders once the private stream has been disconnected
+// for window (3s to 300s). Implements driver.CancelCountdown.
+func (d *Driver) ArmCancelCountdown(ctx context.Context, window time.Duration) error {
+	if d.private == nil {
+		return fmt.Errorf("bybit: API credentials required for ArmCancelCountdown")
+	}
+	if err := d.rest.SetDisconnectCancel(ctx, window); err != nil {
+		return err
+	}
+	return d.private.Subscribe(TopicDCPSpot)
+}
+
+// Subscribe adds a public stream subscription.
+func (d *Driver) Subscribe(sub driver.Subscription) error {
+	topic, err := topicName(sub)
+	if err != nil {
+		return err
+	}
+	return d.public.Subscribe(topic)
+}
+
+// Unsubscribe removes a public stream subscription.
+func (d *Driver) Unsubscribe(sub driver.Subscription) error {
+	topic, err := topicName(sub)
+	if err != nil {
+		return err
+	}
+	return d.public.Unsubscribe(topic)
+}
+
+// topicName maps a driver subscription to a Bybit topic.
+func topicName(sub driver.Subscription) (string, error) {
+	sb := NewStreamBuilder(sub.Symbol)
+	switch sub.Channel {
+	case driver.ChannelTicker:
+		return sb.Ticker(), nil
+	case driver.ChannelOrderBook:
+		return sb.Orderbook50(), nil
+	case driver.ChannelTrade:
+		return sb.Trade(), nil
+	case driver.ChannelKline:
+		interval, err := KlineInterval(sub.Interval)
+		if err != nil {
+			return "", err
+		}
+		return sb.Kline(interval), nil
+	default:
+		return "", errors.NewValidationError("channel", sub.Channel, "unsupported channel")
+	}
+}
@@ -0,0 +1,244 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// maxKlinesLimit is the maximum page size for /v5/market/kline.
+const maxKlinesLimit = 1000
+
+// klineIntervals maps normalized kline intervals to Bybit intervals.
+// Documentation: https://bybit-exchange.github.io/docs/v5/enum#interval
+var klineIntervals = map[string]string{
+	"1m":  "1",
+	"3m":  "3",
+	"5m":  "5",
+	"15m": "15",
+	"30m": "30",
+	"1h":  "60",
+	"2h":  "120",
+	"4h":  "240",
+	"6h":  "360",
+	"12h": "720",
+	"1d":  "D",
+	"1w":  "W",
+	"1M":  "M",
+}
+
+// KlineInterval converts a normalized interval (e.g., "1h") to Bybit's form (e.g., "60").
+func KlineInterval(interval string) (string, error) {
+	if bybitInterval, ok := klineIntervals[interval]; ok {
+		return bybitInterval, nil
+	}
+	return "", errors.NewValidationError("interval", interval, "unsupported kline interval")
+}
+
+// normalizeInterval converts a Bybit interval back to its normalized form.
+// Unknown intervals are returned unchanged.
+func normalizeInterval(bybitInterval string) string {
+	for interval, b := range klineIntervals {
+		if b == bybitInterval {
+			return interval
+		}
+	}
+	return bybitInterval
+}
+
+// klineCloseTime returns the close time of a kline opened at open.
+// Like Binance, the close time is the last millisecond of the kline.
+func klineCloseTime(open time.Time, bybitInterval string) time.Time {
+	var next time.Time
+	switch bybitInterval {
+	case "D":
+		next = open.AddDate(0, 0, 1)
+	case "W":
+		next = open.AddDate(0, 0, 7)
+	case "M":
+		next = open.AddDate(0, 1, 0)
+	default:
+		minutes, err := strconv.Atoi(bybitInterval)
+		if err != nil {
+			return open
+		}
+		next = open.Add(time.Duration(minutes) * time.Minute)
+	}
+	return next.Add(-time.Millisecond)
+}
+
+// GetKlines returns the klines opened between start and end, oldest first.
+// API: GET /v5/market/kline?category=spot
+// Documentation: https://bybit-exchange.github.io/docs/v5/market/kline
+//
+// Bybit returns klines newest first, so ranges longer than one page (1000
+// klines) are walked backwards from end one page at a time. Every page waits
+// on the query rate limiter. A zero end means up to the latest kline. A zero
+// start returns only the latest page ending at end.
+func (rc *RESTClient) GetKlines(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	bybitInterval, err := KlineInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	var klines []domain.Kline // Newest first until reversed
+	cursor := end
+	for {
+		page, err := rc.getKlinesPage(ctx, symbol, bybitInterval, start, cursor)
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, page...)
+
+		if start.IsZero() || len(page) < maxKlinesLimit {
+			break
+		}
+		cursor = page[len(page)-1].OpenTime.Add(-time.Millisecond)
+		if cursor.Before(start) {
+			break
+		}
+	}
+
+	slices.Reverse(klines)
+	return klines, nil
+}
+
+// getKlinesPage fetches a single page of up to maxKlinesLimit klines, newest first.
+func (rc *RESTClient) getKlinesPage(ctx context.Context, symbol, bybitInterval string, start, end time.Time) ([]domain.Kline, error) {
+	params := map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(symbol),
+		"interval": bybitInterval,
+		"limit":    strconv.Itoa(maxKlinesLimit),
+	}
+	if !start.IsZero() {
+		params["start"] = strconv.FormatInt(start.UnixMilli(), 10)
+	}
+	if !end.IsZero() {
+		params["end"] = strconv.FormatInt(end.UnixMilli(), 10)
+	}
+
+	var result struct {
+		Symbol string          `json:"symbol"`
+		List   []KlineResponse `json:"list"`
+	}
+
+	if _, err := rc.get(ctx, EKline, params, &result); err != nil {
+		return nil, err
+	}
+
+	klines := make([]domain.Kline, 0, len(result.List))
+	for i := range result.List {
+		kline, err := result.List[i].ToDomain(exchange, symbol, bybitInterval)
+		if err != nil {
+			return nil, fmt.Errorf("bybit: kline %d: %w", result.List[i].StartTime, err)
+		}
+		klines = append(klines, *kline)
+	}
+
+	return klines, nil
+}
+
+// KlineResponse is a single row returned by /v5/market/kline.
+// Rows are positional string arrays:
+//
+//	[startTime, open, high, low, close, volume, turnover]
+//
+// Documentation: https://bybit-exchange.github.io/docs/v5/market/kline
+type KlineResponse struct {
+	StartTime int64
+	Open      string
+	High      string
+	Low       string
+	Close     string
+	Volume    string
+	Turnover  string
+}
+
+// UnmarshalJSON decodes the positional array form.
+func (k *KlineResponse) UnmarshalJSON(data []byte) error {
+	var row []string
+	if err := json.Unmarshal(data, &row); err != nil {
+		return err
+	}
+	if len(row) < 7 {
+		return fmt.Errorf("kline row has %d fields, want 7", len(row))
+	}
+
+	startTime, err := strconv.ParseInt(row[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse start time: %w", err)
+	}
+
+	*k = KlineResponse{
+		StartTime: startTime,
+		Open:      row[1],
+		High:      row[2],
+		Low:       row[3],
+		Close:     row[4],
+		Volume:    row[5],
+		Turnover:  row[6],
+	}
+	return nil
+}
+
+// ToDomain converts KlineResponse to domain.Kline.
+// REST klines carry no symbol or interval, so both are taken from the request.
+// Bybit does not report trade counts or taker volumes; those fields are zero.
+func (k *KlineResponse) ToDomain(exchange, symbol, bybitInterval string) (*domain.Kline, error) {
+	open, err := domain.NewDecimal(k.Open)
+	if err != nil {
+		return nil, fmt.Errorf("parse open: %w", err)
+	}
+
+	high, err := domain.NewDecimal(k.High)
+	if err != nil {
+		return nil, fmt.Errorf("parse high: %w", err)
+	}
+
+	low, err := domain.NewDecimal(k.Low)
+	if err != nil {
+		return nil, fmt.Errorf("parse low: %w", err)
+	}
+
+	close, err := domain.NewDecimal(k.Close)
+	if err != nil {
+		return nil, fmt.Errorf("parse close: %w", err)
+	}
+
+	volume, err := domain.NewDecimal(k.Volume)
+	if err != nil {
+		return nil, fmt.Errorf("parse volume: %w", err)
+	}
+
+	turnover, err := domain.NewDecimal(k.Turnover)
+	if err != nil {
+		return nil, fmt.Errorf("parse turnover: %w", err)
+	}
+
+	openTime := time.UnixMilli(k.StartTime)
+	closeTime := klineCloseTime(openTime, bybitInterval)
+
+	return &domain.Kline{
+		Exchange:            exchange,
+		Symbol:              symbols.Normalize(symbol),
+		Interval:            normalizeInterval(bybitInterval),
+		OpenTime:            openTime,
+		CloseTime:           closeTime,
+		Open:                open,
+		High:                high,
+		Low:                 low,
+		Close:               close,
+		Volume:              volume,
+		QuoteVolume:         turnover,
+		TakerBuyVolume:      domain.Zero(),
+		TakerBuyQuoteVolume: domain.Zero(),
+		IsClosed:            time.Now().After(closeTime),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package bybit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// PerSecondLimiter implements per-category, per-second rate limiting.
+// Unlike Binance's weight-based system, Bybit limits requests per second
+// per endpoint group (order, account, query).
+//
+// The limiter also honours the X-Bapi-Limit-Status headers: when the server
+// reports no remaining requests, the category is paused until the reset time.
+type PerSecondLimiter struct {
+	mu       sync.RWMutex
+	limiters map[string]*rate.Limiter
+	blocked  map[string]time.Time // category -> reset time reported by server
+}
+
+// NewPerSecondLimiter creates a limiter using EndpointRateLimits.
+func NewPerSecondLimiter() *PerSecondLimiter {
+	pl := &PerSecondLimiter{
+		limiters: make(map[string]*rate.Limiter, len(EndpointRateLimits)),
+		blocked:  make(map[string]time.Time),
+	}
+	for category, limit := range EndpointRateLimits {
+		pl.limiters[category] = rate.NewLimiter(rate.Limit(limit), 1)
+	}
+	return pl
+}
+
+// Wait blocks until a request to endpoint is allowed or ctx is cancelled.
+func (pl *PerSecondLimiter) Wait(ctx context.Context, endpoint string) error {
+	category := EndpointCategory(endpoint)
+
+	pl.mu.RLock()
+	limiter := pl.limiters[category]
+	resetAt := pl.blocked[category]
+	pl.mu.RUnlock()
+
+	// Server said the quota is exhausted - wait for the reset
+	if wait := time.Until(resetAt); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+// SetLimit changes the per-second limit for a category.
+func (pl *PerSecondLimiter) SetLimit(category string, limit int) {
+	if limit <= 0 {
+		return
+	}
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if limiter, ok := pl.limiters[category]; ok {
+		limiter.SetLimit(rate.Limit(limit))
+		return
+	}
+	pl.limiters[category] = rate.NewLimiter(rate.Limit(limit), 1)
+}
+
+// UpdateFromServer records the server-reported remaining quota for an endpoint.
+// If remaining is zero, requests in that category wait until resetAt.
+func (pl *PerSecondLimiter) UpdateFromServer(endpoint string, remaining int, resetAt time.Time) {
+	category := EndpointCategory(endpoint)
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if remaining <= 0 && resetAt.After(time.Now()) {
+		pl.blocked[category] = resetAt
+		return
+	}
+	delete(pl.blocked, category)
+}
@@ -0,0 +1,1117 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/lilwiggy/ex-act/internal/ratelimit"
+	internalSync "github.com/lilwiggy/ex-act/internal/sync"
+	"github.com/lilwiggy/ex-act/internal/util"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+	"resty.dev/v3"
+)
+
+// RESTClient provides authenticated, rate-limited REST communication with the Bybit v5 API.
+// Documentation: https://bybit-exchange.github.io/docs/v5/intro
+// API Version: v5 (verified 2026-02-16)
+//
+// Features:
+//   - Automatic HMAC-SHA256 signing via X-BAPI-* headers
+//   - Signed timestamps from a synchronised clock, resynced on 10002
+//   - Per-second, per-category rate limiting with server header tracking
+//   - Context-based timeouts (not http.Client.Timeout)
+//   - Unwraps the {retCode, retMsg, result} envelope into typed errors
+//
+// IMPORTANT: resty v3 requires calling Close() when done (breaking change from v2)
+type RESTClient struct {
+	client      *resty.Client
+	baseURL     string
+	signer      *Signer
+	rateLimiter *PerSecondLimiter
+	gate        *ratelimit.Gate
+	clock       driver.Clock
+	config      Config
+
+	// Track if client is closed
+	closed   bool
+	closedMu sync.RWMutex
+}
+
+// Config contains configuration for the Bybit REST client.
+type Config struct {
+	// BaseURL is the API base URL (defaults to production)
+	BaseURL string
+	// APIKey is the Bybit API key (required for authenticated requests)
+	APIKey string
+	// APISecret is the Bybit API secret (required for authenticated requests)
+	APISecret string
+	// Timeout is the request timeout (default: 10 seconds)
+	Timeout time.Duration
+	// RecvWindow is the recv window for signed requests in milliseconds (default: 5000)
+	RecvWindow int64
+	// Testnet enables testnet mode (changes base URL)
+	Testnet bool
+	// Gate is the backoff gate shared with the WebSocket clients (default: a new gate)
+	Gate *ratelimit.Gate
+	// Clock is the synchronised clock for signed timestamps (default: a clock
+	// synced from GET /v5/market/time on the first 10002)
+	Clock driver.Clock
+}
+
+// APIResponse is the envelope returned by every v5 endpoint.
+// Bybit returns HTTP 200 for most business errors, so RetCode must be checked.
+// Documentation: https://bybit-exchange.github.io/docs/v5/error
+type APIResponse struct {
+	RetCode int             `json:"retCode"`
+	RetMsg  string          `json:"retMsg"`
+	Result  json.RawMessage `json:"result"`
+	Time    int64           `json:"time"`
+}
+
+// NewRESTClient creates a new Bybit REST client with middleware.
+// IMPORTANT: resty v3 requires calling Close() when done.
+//
+// Example:
+//
+//	cfg := bybit.Config{
+//	    APIKey:    "your-api-key",
+//	    APISecret: "your-api-secret",
+//	    Testnet:   true,
+//	}
+//	client, err := bybit.NewRESTClient(cfg)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Close() // REQUIRED in resty v3
+func NewRESTClient(cfg Config) (*RESTClient, error) {
+	// Set defaults
+	if cfg.BaseURL == "" {
+		if cfg.Testnet {
+			cfg.BaseURL = TestnetRestURL
+		} else {
+			cfg.BaseURL = BaseRestURL
+		}
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.RecvWindow == 0 {
+		cfg.RecvWindow = DefaultRecvWindow
+	}
+	if cfg.Gate == nil {
+		cfg.Gate = ratelimit.NewGate()
+	}
+	var ownClock *internalSync.ClockSync
+	if cfg.Clock == nil {
+		ownClock = internalSync.NewClockSync(exchange, internalSync.ClockConfig{})
+		cfg.Clock = ownClock
+	}
+
+	// Create signer if credentials provided
+	var signer *Signer
+	if cfg.APIKey != "" && cfg.APISecret != "" {
+		signer = NewSigner(cfg.APIKey, cfg.APISecret, cfg.RecvWindow)
+		if err := signer.ValidateCredentials(); err != nil {
+			return nil, err
+		}
+		signer.SetClock(cfg.Clock.UnixMilli)
+	}
+
+	// Create resty client
+	client := resty.New()
+	client.SetBaseURL(cfg.BaseURL)
+
+	client.SetHeader("User-Agent", "ex-act/1.0")
+	client.SetHeader("Content-Type", "application/json")
+	client.SetHeader("Accept", "application/json")
+
+	rc := &RESTClient{
+		client:      client,
+		baseURL:     cfg.BaseURL,
+		signer:      signer,
+		rateLimiter: NewPerSecondLimiter(),
+		gate:        cfg.Gate,
+		clock:       cfg.Clock,
+		config:      cfg,
+	}
+	if ownClock != nil {
+		ownClock.SetTimeProvider(rc.GetServerTime)
+	}
+
+	rc.setupMiddleware()
+
+	return rc, nil
+}
+
+// setupMiddleware configures request/response middleware.
+func (rc *RESTClient) setupMiddleware() {
+	// AddRequestMiddleware: Rate limiting and signing
+	rc.client.AddRequestMiddleware(func(c *resty.Client, req *resty.Request) error {
+		rc.closedMu.RLock()
+		if rc.closed {
+			rc.closedMu.RUnlock()
+			return fmt.Errorf("bybit: client is closed")
+		}
+		rc.closedMu.RUnlock()
+
+		// Hold back every request during an IP rate limit backoff or ban
+		if err := rc.gate.Enter(req.Context()); err != nil {
+			return err
+		}
+
+		endpoint := req.URL
+
+		// Wait for rate limit (blocking)
+		if err := rc.rateLimiter.Wait(req.Context(), endpoint); err != nil {
+			return fmt.Errorf("bybit: rate limit wait failed: %w", err)
+		}
+
+		if rc.signer != nil && needsSigning(endpoint) {
+			// GET signs the query string, POST signs the raw JSON body
+			var payload string
+			if req.Method == http.MethodGet {
+				payload = req.QueryParams.Encode()
+			} else if body, ok := req.Body.([]byte); ok {
+				payload = string(body)
+			}
+
+			timestamp, signature := rc.signer.Sign(payload)
+
+			req.SetHeader("X-BAPI-API-KEY", rc.signer.APIKey())
+			req.SetHeader("X-BAPI-TIMESTAMP", strconv.FormatInt(timestamp, 10))
+			req.SetHeader("X-BAPI-RECV-WINDOW", strconv.FormatInt(rc.signer.RecvWindow(), 10))
+			req.SetHeader("X-BAPI-SIGN", signature)
+		}
+
+		return nil
+	})
+
+	// AddResponseMiddleware: Rate limit tracking
+	rc.client.AddResponseMiddleware(func(c *resty.Client, resp *resty.Response) error {
+		rc.trackLimitFromHeaders(resp.Request.URL, resp.Header())
+		return nil
+	})
+}
+
+// trackLimitFromHeaders reads X-Bapi-Limit-Status and X-Bapi-Limit-Reset-Timestamp.
+func (rc *RESTClient) trackLimitFromHeaders(endpoint string, header http.Header) {
+	remainingStr := header.Get("X-Bapi-Limit-Status")
+	if remainingStr == "" {
+		return
+	}
+	remaining, err := strconv.Atoi(remainingStr)
+	if err != nil {
+		return
+	}
+
+	var resetAt time.Time
+	if resetStr := header.Get("X-Bapi-Limit-Reset-Timestamp"); resetStr != "" {
+		if ms, err := strconv.ParseInt(resetStr, 10, 64); err == nil {
+			resetAt = time.UnixMilli(ms)
+		}
+	}
+
+	rc.rateLimiter.UpdateFromServer(endpoint, remaining, resetAt)
+}
+
+// needsSigning determines if an endpoint requires authentication.
+// Market endpoints are public, trade and account endpoints require signing.
+func needsSigning(endpoint string) bool {
+	return !strings.Contains(endpoint, "/v5/market/")
+}
+
+// Gate returns the backoff gate that holds back requests after an IP rate limit.
+func (rc *RESTClient) Gate() *ratelimit.Gate {
+	return rc.gate
+}
+
+// Close releases resources used by the client.
+// REQUIRED by resty v3 - must be called when done with the client.
+func (rc *RESTClient) Close() {
+	rc.closedMu.Lock()
+	rc.closed = true
+	rc.closedMu.Unlock()
+	rc.client.Close()
+}
+
+// get performs a GET request and decodes the result field into result.
+func (rc *RESTClient) get(ctx context.Context, endpoint string, params map[string]string, result any) (*APIResponse, error) {
+	return rc.retryOnClockSkew(func() (*APIResponse, error) {
+		var envelope APIResponse
+
+		resp, err := rc.client.R().
+			SetContext(ctx).
+			SetQueryParams(params).
+			SetResult(&envelope).
+			Get(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		return rc.decode(resp, &envelope, result)
+	})
+}
+
+// post performs a POST request with a JSON body and decodes the result field into result.
+// The body is marshalled here so the signed payload matches the bytes on the wire.
+func (rc *RESTClient) post(ctx context.Context, endpoint string, body any, result any) (*APIResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("bybit: marshal request: %w", err)
+	}
+
+	return rc.retryOnClockSkew(func() (*APIResponse, error) {
+		var envelope APIResponse
+
+		resp, err := rc.client.R().
+			SetContext(ctx).
+			SetBody(payload).
+			SetResult(&envelope).
+			Post(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		return rc.decode(resp, &envelope, result)
+	})
+}
+
+// retryOnClockSkew sends a request and, if the server rejects the timestamp
+// (10002, outside recv_window), resyncs the clock and sends it once more.
+// send must build a new request each time so it is re-signed.
+func (rc *RESTClient) retryOnClockSkew(send func() (*APIResponse, error)) (*APIResponse, error) {
+	envelope, err := send()
+
+	var clockErr *errors.ClockSyncError
+	if !errors.As(err, &clockErr) {
+		return envelope, err
+	}
+	// A ClockSyncError from Sync still applies the measured offset
+	if syncErr := rc.clock.Sync(); syncErr != nil && !errors.As(syncErr, &clockErr) {
+		return nil, err
+	}
+	return send()
+}
+
+// Clock returns the clock used for signed request timestamps.
+func (rc *RESTClient) Clock() driver.Clock {
+	return rc.clock
+}
+
+// decode checks the HTTP status and retCode, then unmarshals the result payload.
+func (rc *RESTClient) decode(resp *resty.Response, envelope *APIResponse, result any) (*APIResponse, error) {
+	if !resp.IsSuccess() {
+		return nil, rc.handleErrorResponse(resp)
+	}
+
+	if envelope.RetCode != 0 {
+		return nil, rc.createBybitError(resp.StatusCode(), envelope.RetCode, envelope.RetMsg)
+	}
+
+	if result != nil && len(envelope.Result) > 0 {
+		if err := json.Unmarshal(envelope.Result, result); err != nil {
+			return nil, fmt.Errorf("bybit: decode result: %w", err)
+		}
+	}
+
+	return envelope, nil
+}
+
+// handleErrorResponse converts HTTP error responses to typed errors.
+func (rc *RESTClient) handleErrorResponse(resp *resty.Response) error {
+	statusCode := resp.StatusCode()
+
+	var bodyBytes []byte
+	if resp.Body != nil {
+		bodyBytes, _ = io.ReadAll(resp.Body)
+	}
+
+	var envelope APIResponse
+	if err := json.Unmarshal(bodyBytes, &envelope); err == nil && envelope.RetMsg != "" {
+		return rc.createBybitError(statusCode, envelope.RetCode, envelope.RetMsg)
+	}
+
+	if statusCode == http.StatusForbidden {
+		// Bybit returns 403 when the IP rate limit is breached; the IP is
+		// then blocked for at least 10 minutes
+		err := errors.NewIPBanError(exchange, string(bodyBytes), ipBanBackoff)
+		rc.gate.Close(err.Until, err)
+		return err
+	}
+	if statusCode == http.StatusTooManyRequests {
+		return errors.NewRateLimitError(exchange, 10*time.Second, 1)
+	}
+
+	return errors.NewConnectionError(exchange, resp.Request.URL, fmt.Sprintf("HTTP %d: %s", statusCode, string(bodyBytes)), false)
+}
+
+// createBybitError creates an appropriate error type based on Bybit error codes.
+// Documentation: https://bybit-exchange.github.io/docs/v5/error
+func (rc *RESTClient) createBybitError(httpStatus, code int, msg string) error {
+	switch code {
+	case 10006:
+		// Too many visits (per-endpoint limit)
+		return errors.NewRateLimitError(exchange, 1*time.Second, 1)
+	case 10018:
+		// Exceeded the IP rate limit: back off every request
+		err := errors.NewRateLimitError(exchange, 1*time.Second, 1)
+		rc.gate.Close(time.Now().Add(err.RetryAfter), err)
+		return err
+	case 10003, 10004, 10005, 10007, 10009, 10010:
+		// Invalid key, sign error, permission denied, auth failed, IP banned key, unmatched IP
+		return fmt.Errorf("bybit: authentication failed: %s", msg)
+	case 10001:
+		return errors.NewValidationError("request", nil, msg)
+	case 10002:
+		// Timestamp outside recv_window: the local clock has drifted from the server
+		err := errors.NewClockSyncError(exchange, time.Now(), time.UnixMilli(rc.clock.UnixMilli()), 0)
+		err.Message = msg
+		return err
+	}
+
+	err := errors.NewExchangeError(exchange, "", fmt.Sprintf("error code %d: %s", code, msg), nil)
+	err.Code = strconv.Itoa(code)
+	return err
+}
+
+// Ping tests connectivity to the Bybit API.
+// Bybit has no dedicated ping endpoint, so this calls the server time endpoint.
+// API: GET /v5/market/time
+func (rc *RESTClient) Ping(ctx context.Context) error {
+	_, err := rc.GetServerTime(ctx)
+	return err
+}
+
+// GetServerTime returns the current server time in milliseconds.
+// API: GET /v5/market/time
+// Documentation: https://bybit-exchange.github.io/docs/v5/market/time
+func (rc *RESTClient) GetServerTime(ctx context.Context) (int64, error) {
+	var result struct {
+		TimeSecond string `json:"timeSecond"`
+		TimeNano   string `json:"timeNano"`
+	}
+
+	envelope, err := rc.get(ctx, ETime, nil, &result)
+	if err != nil {
+		return 0, err
+	}
+
+	if nanos, err := strconv.ParseInt(result.TimeNano, 10, 64); err == nil {
+		return nanos / int64(time.Millisecond), nil
+	}
+	return envelope.Time, nil
+}
+
+// ExchangeInfo represents the spot instruments-info response.
+// Documentation: https://bybit-exchange.github.io/docs/v5/market/instrument
+type ExchangeInfo struct {
+	Category       string           `json:"category"`
+	List           []InstrumentInfo `json:"list"`
+	NextPageCursor string           `json:"nextPageCursor"`
+}
+
+// InstrumentInfo represents a single spot instrument.
+type InstrumentInfo struct {
+	Symbol        string `json:"symbol"`
+	BaseCoin      string `json:"baseCoin"`
+	QuoteCoin     string `json:"quoteCoin"`
+	Innovation    string `json:"innovation"`
+	Status        string `json:"status"` // Trading, PreLaunch, Delivering, Closed
+	MarginTrading string `json:"marginTrading"`
+	LotSizeFilter struct {
+		BasePrecision  string `json:"basePrecision"`
+		QuotePrecision string `json:"quotePrecision"`
+		MinOrderQty    string `json:"minOrderQty"`
+		MaxOrderQty    string `json:"maxOrderQty"`
+		MinOrderAmt    string `json:"minOrderAmt"`
+		MaxOrderAmt    string `json:"maxOrderAmt"`
+	} `json:"lotSizeFilter"`
+	PriceFilter struct {
+		TickSize string `json:"tickSize"`
+	} `json:"priceFilter"`
+}
+
+// ToDomain converts ExchangeInfo to domain.ExchangeInfo.
+func (e *ExchangeInfo) ToDomain(serverTime time.Time) *domain.ExchangeInfo {
+	info := &domain.ExchangeInfo{
+		Exchange:   exchange,
+		ServerTime: serverTime,
+		Symbols:    make([]domain.SymbolInfo, 0, len(e.List)),
+	}
+	for i := range e.List {
+		info.Symbols = append(info.Symbols, e.List[i].ToDomain())
+	}
+	return info
+}
+
+// ToDomain converts InstrumentInfo to domain.SymbolInfo.
+// Bybit expresses precision as a step (e.g., "0.000001"), so the precision
+// fields are derived from the step exponent.
+func (i *InstrumentInfo) ToDomain() domain.SymbolInfo {
+	basePrecision := optionalDecimal(i.LotSizeFilter.BasePrecision)
+	quotePrecision := optionalDecimal(i.LotSizeFilter.QuotePrecision)
+	minQty := optionalDecimal(i.LotSizeFilter.MinOrderQty)
+	maxQty := optionalDecimal(i.LotSizeFilter.MaxOrderQty)
+	tickSize := optionalDecimal(i.PriceFilter.TickSize)
+	minAmt := optionalDecimal(i.LotSizeFilter.MinOrderAmt)
+
+	return domain.SymbolInfo{
+		Exchange:            exchange,
+		Symbol:              domain.FormatSymbol(i.BaseCoin, i.QuoteCoin),
+		BaseAsset:           i.BaseCoin,
+		QuoteAsset:          i.QuoteCoin,
+		ExchangeSymbol:      i.Symbol,
+		Status:              i.Status,
+		BaseAssetPrecision:  stepPrecision(basePrecision),
+		QuoteAssetPrecision: stepPrecision(quotePrecision),
+		MinQuantity:         minQty,
+		MaxQuantity:         maxQty,
+		QuantityStep:        basePrecision,
+		PriceStep:           tickSize,
+		MinNotional:         minAmt,
+
+		// Bybit spot takes limit and market orders; post-only is a time in force
+		OrderTypes:           []string{string(domain.OrderTypeLimit), string(domain.OrderTypeMarket)},
+		Permissions:          i.permissions(),
+		SpotTradingAllowed:   true,
+		MarginTradingAllowed: i.marginAllowed(),
+
+		Filters: domain.SymbolFilters{
+			// Bybit has no price bounds on spot, only the tick size
+			Price: &domain.PriceFilter{TickSize: tickSize},
+			LotSize: &domain.LotSizeFilter{
+				MinQuantity: minQty,
+				MaxQuantity: maxQty,
+				StepSize:    basePrecision,
+			},
+			// The order amount limits apply to market orders as well
+			Notional: &domain.NotionalFilter{
+				MinNotional:      minAmt,
+				MaxNotional:      optionalDecimal(i.LotSizeFilter.MaxOrderAmt),
+				ApplyMinToMarket: true,
+				ApplyMaxToMarket: true,
+			},
+		},
+	}
+}
+
+// marginAllowed returns true if the instrument trades on margin.
+// marginTrading is one of none, both, utaOnly or normalSpotOnly.
+func (i *InstrumentInfo) marginAllowed() bool {
+	return i.MarginTrading != "" && i.MarginTrading != "none"
+}
+
+// permissions returns the account permissions that may trade the instrument,
+// named as on Binance.
+func (i *InstrumentInfo) permissions() []string {
+	if i.marginAllowed() {
+		return []string{"SPOT", "MARGIN"}
+	}
+	return []string{"SPOT"}
+}
+
+// optionalDecimal parses a decimal string, returning nil if empty or invalid.
+func optionalDecimal(s string) domain.Decimal {
+	if s == "" {
+		return nil
+	}
+	d, err := domain.NewDecimal(s)
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// stepPrecision returns the number of decimal places in a step size.
+func stepPrecision(step domain.Decimal) int {
+	if step == nil {
+		return 0
+	}
+	var reduced apd.Decimal
+	reduced.Reduce(step)
+	if reduced.Exponent >= 0 {
+		return 0
+	}
+	return int(-reduced.Exponent)
+}
+
+// GetExchangeInfo returns the spot instruments selected by the query.
+// Bybit filters by a single symbol only; other symbol lists and permissions
+// are applied to the full listing.
+// API: GET /v5/market/instruments-info?category=spot
+// Documentation: https://bybit-exchange.github.io/docs/v5/market/instrument
+func (rc *RESTClient) GetExchangeInfo(ctx context.Context, query driver.ExchangeInfoQuery) (*ExchangeInfo, error) {
+	var result ExchangeInfo
+
+	params := map[string]string{"category": CategorySpot}
+	if len(query.Symbols) == 1 {
+		params["symbol"] = symbols.ExchangeSymbol(query.Symbols[0])
+	}
+	if _, err := rc.get(ctx, EInstruments, params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(query.Symbols) > 1 {
+		wanted := make(map[string]bool, len(query.Symbols))
+		for _, symbol := range query.Symbols {
+			wanted[symbols.ExchangeSymbol(symbol)] = true
+		}
+		result.List = slices.DeleteFunc(result.List, func(i InstrumentInfo) bool {
+			return !wanted[i.Symbol]
+		})
+	}
+	if len(query.Permissions) > 0 {
+		result.List = slices.DeleteFunc(result.List, func(i InstrumentInfo) bool {
+			return !slices.ContainsFunc(query.Permissions, func(p string) bool {
+				return slices.Contains(i.permissions(), p)
+			})
+		})
+	}
+
+	return &result, nil
+}
+
+// GetTicker returns the 24h ticker for a symbol.
+// API: GET /v5/market/tickers?category=spot&symbol=<symbol>
+// Documentation: https://bybit-exchange.github.io/docs/v5/market/tickers
+func (rc *RESTClient) GetTicker(ctx context.Context, symbol string) (*domain.Ticker, error) {
+	var result struct {
+		List []WSTicker `json:"list"`
+	}
+
+	envelope, err := rc.get(ctx, ETickers, map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(symbol),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.List) == 0 {
+		return nil, errors.NewNotFoundError("ticker", symbol)
+	}
+
+	return result.List[0].ToDomain(exchange, envelope.Time)
+}
+
+// GetOrderBook returns an order book snapshot.
+// API: GET /v5/market/orderbook?category=spot&symbol=<symbol>&limit=<depth>
+// Documentation: https://bybit-exchange.github.io/docs/v5/market/orderbook
+// Spot depth range is 1-200.
+func (rc *RESTClient) GetOrderBook(ctx context.Context, symbol string, depth int) (*domain.OrderBook, error) {
+	var result struct {
+		WSOrderbookData
+		TS int64 `json:"ts"`
+	}
+
+	params := map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(symbol),
+	}
+	if depth > 0 {
+		params["limit"] = strconv.Itoa(depth)
+	}
+
+	if _, err := rc.get(ctx, EOrderbook, params, &result); err != nil {
+		return nil, err
+	}
+
+	bids, asks, err := result.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OrderBook{
+		Exchange:     exchange,
+		Symbol:       symbols.Normalize(result.Symbol),
+		Bids:         bids,
+		Asks:         asks,
+		LastUpdateID: result.UpdateID,
+		Timestamp:    time.UnixMilli(result.TS),
+	}, nil
+}
+
+// GetAccount returns the unified trading account wallet balance.
+// API: GET /v5/account/wallet-balance?accountType=UNIFIED (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/account/wallet-balance
+func (rc *RESTClient) GetAccount(ctx context.Context) (*AccountInfo, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("bybit: API credentials required for GetAccount")
+	}
+
+	var result struct {
+		List []AccountInfo `json:"list"`
+	}
+
+	if _, err := rc.get(ctx, EWalletBalance, map[string]string{"accountType": "UNIFIED"}, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.List) == 0 {
+		return nil, errors.NewNotFoundError("account", "UNIFIED")
+	}
+
+	return &result.List[0], nil
+}
+
+// AccountInfo represents a wallet balance entry.
+type AccountInfo struct {
+	AccountType           string        `json:"accountType"`
+	TotalEquity           string        `json:"totalEquity"`
+	TotalWalletBalance    string        `json:"totalWalletBalance"`
+	TotalAvailableBalance string        `json:"totalAvailableBalance"`
+	Coins                 []CoinBalance `json:"coin"`
+}
+
+// CoinBalance represents a single coin in the wallet.
+type CoinBalance struct {
+	Coin          string `json:"coin"`
+	WalletBalance string `json:"walletBalance"`
+	Locked        string `json:"locked"`
+	Equity        string `json:"equity"`
+}
+
+// ToDomain converts CoinBalance to domain.Balance.
+// Free is the wallet balance minus the amount locked in open orders.
+func (b *CoinBalance) ToDomain() (*domain.Balance, error) {
+	wallet, err := parseOptionalDecimal(b.WalletBalance)
+	if err != nil {
+		return nil, fmt.Errorf("parse wallet_balance: %w", err)
+	}
+	locked, err := parseOptionalDecimal(b.Locked)
+	if err != nil {
+		return nil, fmt.Errorf("parse locked: %w", err)
+	}
+	return &domain.Balance{
+		Exchange:  exchange,
+		Asset:     b.Coin,
+		Free:      domain.Sub(wallet, locked),
+		Locked:    locked,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// PlaceOrder places a new spot order.
+// API: POST /v5/order/create (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/create-order
+// Bybit only acknowledges with orderId/orderLinkId; the returned order has status NEW.
+// A StopPrice places a conditional order (orderFilter=StopOrder) triggered at that price.
+// Unsupported order types and times in force are rejected before sending.
+func (rc *RESTClient) PlaceOrder(ctx context.Context, req *domain.OrderRequest) (*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("bybit: API credentials required for PlaceOrder")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	orderType, err := formatOrderType(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]string{
+		"category":  CategorySpot,
+		"symbol":    symbols.ExchangeSymbol(req.Symbol),
+		"side":      formatSide(req.Side),
+		"orderType": orderType,
+	}
+	if req.Quantity != nil && !domain.IsZero(req.Quantity) {
+		body["qty"] = req.Quantity.String()
+		if req.Type == domain.OrderTypeMarket {
+			body["marketUnit"] = "baseCoin"
+		}
+	} else if req.QuoteQuantity != nil {
+		body["qty"] = req.QuoteQuantity.String()
+		body["marketUnit"] = "quoteCoin"
+	}
+	if req.Type == domain.OrderTypeLimit {
+		body["price"] = req.Price.String()
+		body["timeInForce"] = "GTC"
+	}
+	if req.TimeInForce != "" {
+		tif, err := formatTimeInForce(req.TimeInForce)
+		if err != nil {
+			return nil, err
+		}
+		body["timeInForce"] = tif
+	}
+	if req.StopPrice != nil && !domain.IsZero(req.StopPrice) {
+		// Conditional order, placed once the last price crosses triggerPrice
+		body["triggerPrice"] = req.StopPrice.String()
+		body["orderFilter"] = "StopOrder"
+	}
+	if req.ClientOrderID != "" {
+		body["orderLinkId"] = req.ClientOrderID
+	}
+
+	var result struct {
+		OrderID     string `json:"orderId"`
+		OrderLinkID string `json:"orderLinkId"`
+	}
+
+	if _, err := rc.post(ctx, ECreateOrder, body, &result); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &domain.Order{
+		Exchange:       exchange,
+		Symbol:         symbols.Normalize(req.Symbol),
+		ID:             result.OrderID,
+		ClientOrderID:  result.OrderLinkID,
+		Side:           req.Side,
+		Type:           req.Type,
+		Status:         domain.OrderStatusNew,
+		Price:          util.OrZero(req.Price),
+		Quantity:       util.OrZero(req.Quantity),
+		FilledQuantity: domain.Zero(),
+		QuoteQuantity:  domain.Zero(),
+		Commission:     domain.Zero(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		IsWorking:      true,
+	}, nil
+}
+
+// formatOrderType converts domain.OrderType to Bybit format.
+// Documentation: https://bybit-exchange.github.io/docs/v5/enum#ordertype
+func formatOrderType(t domain.OrderType) (string, error) {
+	switch t {
+	case domain.OrderTypeLimit:
+		return "Limit", nil
+	case domain.OrderTypeMarket:
+		return "Market", nil
+	default:
+		return "", errors.NewValidationError("type", t, "unsupported order type")
+	}
+}
+
+// formatTimeInForce converts a time in force to Bybit format.
+// Post-only, named GTX or LIMIT_MAKER on other exchanges, is "PostOnly".
+// Documentation: https://bybit-exchange.github.io/docs/v5/enum#timeinforce
+func formatTimeInForce(tif string) (string, error) {
+	switch strings.ToUpper(tif) {
+	case "GTC":
+		return "GTC", nil
+	case "IOC":
+		return "IOC", nil
+	case "FOK":
+		return "FOK", nil
+	case "POSTONLY", "POST_ONLY", "GTX", "LIMIT_MAKER":
+		return "PostOnly", nil
+	default:
+		return "", errors.NewValidationError("time_in_force", tif, "unsupported time in force")
+	}
+}
+
+// CancelOrder cancels an open spot order.
+// API: POST /v5/order/cancel (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/cancel-order
+// Bybit acknowledges the cancel asynchronously; the returned order has status
+// CANCELING and the final CANCELED state arrives on the private order stream.
+func (rc *RESTClient) CancelOrder(ctx context.Context, req *domain.CancelRequest) (*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("bybit: API credentials required for CancelOrder")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	body := map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(req.Symbol),
+	}
+	if req.OrderID != "" {
+		body["orderId"] = req.OrderID
+	}
+	if req.ClientOrderID != "" {
+		body["orderLinkId"] = req.ClientOrderID
+	}
+
+	var result cancelAck
+
+	if _, err := rc.post(ctx, ECancelOrder, body, &result); err != nil {
+		return nil, err
+	}
+
+	return result.toDomain(req.Symbol), nil
+}
+
+// CancelAllOrders cancels all open spot orders for a symbol.
+// API: POST /v5/order/cancel-all (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/cancel-all
+func (rc *RESTClient) CancelAllOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("bybit: API credentials required for CancelAllOrders")
+	}
+
+	body := map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(symbol),
+	}
+
+	var result struct {
+		List []cancelAck `json:"list"`
+	}
+
+	if _, err := rc.post(ctx, ECancelAllOrders, body, &result); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*domain.Order, 0, len(result.List))
+	for i := range result.List {
+		orders = append(orders, result.List[i].toDomain(symbol))
+	}
+	return orders, nil
+}
+
+// SetDisconnectCancel configures Disconnect Cancel Protection (DCP) for spot:
+// once the private WebSocket has been disconnected for window, the exchange
+// cancels all open spot orders. DCP applies to private connections subscribed
+// to the dcp.spot topic.
+// API: POST /v5/order/disconnected-cancel-all (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/dcp
+//
+// window is rounded down to whole seconds and must be between 3s and 300s.
+func (rc *RESTClient) SetDisconnectCancel(ctx context.Context, window time.Duration) error {
+	if rc.signer == nil {
+		return fmt.Errorf("bybit: API credentials required for SetDisconnectCancel")
+	}
+	if window < minDCPWindow || window > maxDCPWindow {
+		return errors.NewValidationError("time_window", window, fmt.Sprintf("must be between %s and %s", minDCPWindow, maxDCPWindow))
+	}
+
+	body := map[string]any{
+		"product":    "SPOT",
+		"timeWindow": int(window / time.Second),
+	}
+
+	_, err := rc.post(ctx, EDisconnectCancelAll, body, nil)
+	return err
+}
+
+// cancelAck is a single cancel acknowledgement.
+type cancelAck struct {
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+}
+
+// toDomain converts a cancel acknowledgement to a CANCELING order.
+func (a *cancelAck) toDomain(symbol string) *domain.Order {
+	return &domain.Order{
+		Exchange:      exchange,
+		Symbol:        symbols.Normalize(symbol),
+		ID:            a.OrderID,
+		ClientOrderID: a.OrderLinkID,
+		Status:        domain.OrderStatusCanceling,
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// GetOrder returns a single order by exchange order ID.
+// API: GET /v5/order/realtime (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/open-order
+// Recently closed orders are also returned by this endpoint.
+func (rc *RESTClient) GetOrder(ctx context.Context, symbol, orderID string) (*domain.Order, error) {
+	orders, err := rc.queryOrders(ctx, map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(symbol),
+		"orderId":  orderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, errors.NewNotFoundError("order", orderID)
+	}
+	return orders[0], nil
+}
+
+// GetOpenOrders returns all open orders, optionally filtered by symbol.
+// API: GET /v5/order/realtime?openOnly=0 (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/open-order
+// Every page is read; the exchange returns at most 50 orders per page.
+func (rc *RESTClient) GetOpenOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	params := map[string]string{
+		"category": CategorySpot,
+		"openOnly": "0",
+		"limit":    strconv.Itoa(maxOpenOrdersLimit),
+	}
+	if symbol != "" {
+		params["symbol"] = symbols.ExchangeSymbol(symbol)
+	}
+
+	var orders []*domain.Order
+	for {
+		page, cursor, err := rc.queryOrdersPage(ctx, EQueryOrder, params)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, page...)
+
+		if cursor == "" || len(page) == 0 {
+			break
+		}
+		params["cursor"] = cursor
+	}
+	return orders, nil
+}
+
+// GetOrderHistory returns closed and open orders for a symbol.
+// API: GET /v5/order/history (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/order-list
+// Zero times are omitted (the exchange returns the last 7 days). limit is capped at 50.
+func (rc *RESTClient) GetOrderHistory(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.Order, error) {
+	params := map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(symbol),
+	}
+	if !startTime.IsZero() {
+		params["startTime"] = strconv.FormatInt(startTime.UnixMilli(), 10)
+	}
+	if !endTime.IsZero() {
+		params["endTime"] = strconv.FormatInt(endTime.UnixMilli(), 10)
+	}
+	if limit > 0 {
+		params["limit"] = strconv.Itoa(min(limit, maxOrderHistoryLimit))
+	}
+	return rc.queryOrdersAt(ctx, EOrderHistory, params)
+}
+
+// GetExecutions returns spot fills for a symbol, oldest first.
+// API: GET /v5/execution/list (signed)
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/execution
+//
+// The exchange pages newest first, so every page of a window is read before
+// limit is applied. A zero since is omitted (the exchange returns the last
+// 7 days); otherwise 7-day windows from since are read in turn until limit
+// trades are found or a window reaches now.
+// Executions other than trades (e.g., funding) are skipped.
+func (rc *RESTClient) GetExecutions(ctx context.Context, symbol string, since time.Time, limit int) ([]domain.Trade, error) {
+	if rc.signer == nil {
+		return nil, fmt.Errorf("bybit: API credentials required for GetExecutions")
+	}
+
+	params := map[string]string{
+		"category": CategorySpot,
+		"symbol":   symbols.ExchangeSymbol(symbol),
+		"limit":    strconv.Itoa(maxExecutionsLimit),
+	}
+
+	var trades []domain.Trade
+	if since.IsZero() {
+		window, err := rc.executions(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		trades = window
+	} else {
+		now := time.Now()
+		for start := since; ; {
+			end := start.Add(executionsWindow)
+			params["startTime"] = strconv.FormatInt(start.UnixMilli(), 10)
+			params["endTime"] = strconv.FormatInt(end.UnixMilli()-1, 10)
+			delete(params, "cursor")
+
+			window, err := rc.executions(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			trades = append(trades, window...)
+
+			if (limit > 0 && len(trades) >= limit) || !end.Before(now) {
+				break
+			}
+			start = end
+		}
+	}
+
+	if limit > 0 && len(trades) > limit {
+		trades = trades[:limit]
+	}
+	return trades, nil
+}
+
+// executions reads every page of /v5/execution/list for params and returns
+// the trades oldest first.
+func (rc *RESTClient) executions(ctx context.Context, params map[string]string) ([]domain.Trade, error) {
+	var trades []domain.Trade
+	for {
+		var result struct {
+			List           []WSExecution `json:"list"`
+			NextPageCursor string        `json:"nextPageCursor"`
+		}
+
+		if _, err := rc.get(ctx, EExecutions, params, &result); err != nil {
+			return nil, err
+		}
+
+		for i := range result.List {
+			if result.List[i].ExecType != ExecTypeTrade {
+				continue
+			}
+			trade, err := result.List[i].ToDomain(exchange)
+			if err != nil {
+				return nil, err
+			}
+			trades = append(trades, *trade)
+		}
+
+		if result.NextPageCursor == "" || len(result.List) == 0 {
+			break
+		}
+		params["cursor"] = result.NextPageCursor
+	}
+
+	slices.Reverse(trades)
+	return trades, nil
+}
+
+// queryOrders calls /v5/order/realtime and converts the result list.
+func (rc *RESTClient) queryOrders(ctx context.Context, params map[string]string) ([]*domain.Order, error) {
+	return rc.queryOrdersAt(ctx, EQueryOrder, params)
+}
+
+// queryOrdersAt calls an order list endpoint and converts the result list.
+func (rc *RESTClient) queryOrdersAt(ctx context.Context, endpoint string, params map[string]string) ([]*domain.Order, error) {
+	orders, _, err := rc.queryOrdersPage(ctx, endpoint, params)
+	return orders, err
+}
+
+// queryOrdersPage calls an order list endpoint and converts one page of the
+// result list. Returns the cursor of the next page, empty on the last page.
+func (rc *RESTClient) queryOrdersPage(ctx context.Context, endpoint string, params map[string]string) ([]*domain.Order, string, error) {
+	if rc.signer == nil {
+		return nil, "", fmt.Errorf("bybit: API credentials required for order queries")
+	}
+
+	var result struct {
+		List           []WSOrderUpdate `json:"list"`
+		NextPageCursor string          `json:"nextPageCursor"`
+	}
+
+	if _, err := rc.get(ctx, endpoint, params, &result); err != nil {
+		return nil, "", err
+	}
+
+	orders := make([]*domain.Order, 0, len(result.List))
+	for i := range result.List {
+		order, err := result.List[i].ToDomain(exchange)
+		if err != nil {
+			return nil, "", err
+		}
+		orders = append(orders, order)
+	}
+	return orders, result.NextPageCursor, nil
+}
@@ -0,0 +1,168 @@
+// Package bybit implements the Bybit exchange driver.
+// Subscription management for Bybit WebSocket topics.
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/connect
+package bybit
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// TopicOrder is the private order update topic.
+	TopicOrder = "order"
+	// TopicExecution is the private execution (fill) topic.
+	TopicExecution = "execution"
+	// TopicWallet is the private wallet balance topic.
+	TopicWallet = "wallet"
+	// TopicDCPSpot enables Disconnect Cancel Protection for spot on the private connection.
+	TopicDCPSpot = "dcp.spot"
+)
+
+// SubscriptionManager manages WebSocket topic subscriptions.
+// It tracks active subscriptions for automatic resubscription on reconnect.
+// CRITICAL: Must be thread-safe (sync.RWMutex) for concurrent access.
+type SubscriptionManager struct {
+	mu            sync.RWMutex
+	subscriptions map[string]bool
+}
+
+// NewSubscriptionManager creates a new SubscriptionManager.
+func NewSubscriptionManager() *SubscriptionManager {
+	return &SubscriptionManager{
+		subscriptions: make(map[string]bool),
+	}
+}
+
+// Subscribe adds a topic to subscriptions.
+// Returns true if this is a new subscription, false if already subscribed.
+// Topics are case-sensitive on Bybit and are stored as given.
+func (sm *SubscriptionManager) Subscribe(topic string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.subscriptions[topic] {
+		return false // Already subscribed
+	}
+	sm.subscriptions[topic] = true
+	return true
+}
+
+// Unsubscribe removes a topic from subscriptions.
+// Returns true if the topic was subscribed, false otherwise.
+func (sm *SubscriptionManager) Unsubscribe(topic string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if !sm.subscriptions[topic] {
+		return false // Not subscribed
+	}
+	delete(sm.subscriptions, topic)
+	return true
+}
+
+// IsSubscribed checks if a topic is subscribed.
+func (sm *SubscriptionManager) IsSubscribed(topic string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.subscriptions[topic]
+}
+
+// Topics returns all subscribed topic names.
+// Used for automatic resubscription on reconnect.
+func (sm *SubscriptionManager) Topics() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	topics := make([]string, 0, len(sm.subscriptions))
+	for topic := range sm.subscriptions {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// Count returns the number of active subscriptions.
+func (sm *SubscriptionManager) Count() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return len(sm.subscriptions)
+}
+
+// Clear removes all subscriptions.
+func (sm *SubscriptionManager) Clear() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.subscriptions = make(map[string]bool)
+}
+
+// StreamBuilder creates Bybit WebSocket topic names.
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/public/orderbook
+// Topic symbols MUST be uppercase for Bybit.
+type StreamBuilder struct {
+	symbol string // Exchange symbol format (e.g., "BTCUSDT")
+}
+
+// NewStreamBuilder creates a StreamBuilder for the given symbol.
+// Accepts both exchange ("BTCUSDT") and normalized ("BTC/USDT") formats.
+func NewStreamBuilder(symbol string) *StreamBuilder {
+	return &StreamBuilder{
+		symbol: symbols.ExchangeSymbol(symbol),
+	}
+}
+
+// Ticker creates a ticker topic name.
+// Topic: tickers.<symbol>
+// Spot tickers are pushed as snapshots every 50ms.
+func (sb *StreamBuilder) Ticker() string {
+	return "tickers." + sb.symbol
+}
+
+// Orderbook creates an order book topic name with the given depth.
+// Topic: orderbook.<depth>.<symbol>
+// Valid spot depths: 1, 50, 200, 1000
+func (sb *StreamBuilder) Orderbook(depth int) string {
+	return "orderbook." + strconv.Itoa(depth) + "." + sb.symbol
+}
+
+// Orderbook50 creates a 50-level order book topic name.
+// Topic: orderbook.50.<symbol>
+// Pushed every 20ms as snapshot followed by deltas.
+func (sb *StreamBuilder) Orderbook50() string {
+	return sb.Orderbook(50)
+}
+
+// Trade creates a public trade topic name.
+// Topic: publicTrade.<symbol>
+func (sb *StreamBuilder) Trade() string {
+	return "publicTrade." + sb.symbol
+}
+
+// Kline creates a kline topic name.
+// Topic: kline.<interval>.<symbol>
+// Valid intervals: 1, 3, 5, 15, 30, 60, 120, 240, 360, 720, D, W, M
+func (sb *StreamBuilder) Kline(interval string) string {
+	return "kline." + interval + "." + sb.symbol
+}
+
+// ParseTopicType extracts the topic type from a topic name.
+// Example: "orderbook.50.BTCUSDT" -> "orderbook", "order" -> "order"
+func ParseTopicType(topic string) string {
+	if idx := strings.Index(topic, "."); idx >= 0 {
+		return topic[:idx]
+	}
+	return topic
+}
+
+// ParseTopicSymbol extracts the symbol from a topic name.
+// Returns empty string for private topics without a symbol.
+func ParseTopicSymbol(topic string) string {
+	idx := strings.LastIndex(topic, ".")
+	if idx < 0 || idx == len(topic)-1 {
+		return ""
+	}
+	return topic[idx+1:]
+}
@@ -0,0 +1,121 @@
+// Package bybit implements the Bybit exchange driver.
+// API Documentation: https://bybit-exchange.github.io/docs/v5/intro
+// API Version: v5 (verified 2026-02-16)
+package bybit
+
+import (
+	"strings"
+	"time"
+)
+
+// Bybit API base URLs
+const (
+	// BaseRestURL is the production REST API base URL
+	BaseRestURL = "https://api.bybit.com"
+	// BasePublicSpotWSURL is the production public spot WebSocket URL
+	BasePublicSpotWSURL = "wss://stream.bybit.com/v5/public/spot"
+	// BasePrivateWSURL is the production private WebSocket URL
+	BasePrivateWSURL = "wss://stream.bybit.com/v5/private"
+	// TestnetRestURL is the testnet REST API base URL
+	TestnetRestURL = "https://api-testnet.bybit.com"
+	// TestnetPublicSpotWSURL is the testnet public spot WebSocket URL
+	TestnetPublicSpotWSURL = "wss://stream-testnet.bybit.com/v5/public/spot"
+	// TestnetPrivateWSURL is the testnet private WebSocket URL
+	TestnetPrivateWSURL = "wss://stream-testnet.bybit.com/v5/private"
+)
+
+// CategorySpot is the product category for all spot requests.
+// Every v5 market and trade endpoint requires a category parameter.
+const CategorySpot = "spot"
+
+// ipBanBackoff is how long Bybit blocks an IP after a 403 rate limit breach.
+// Documentation: https://bybit-exchange.github.io/docs/v5/rate-limit
+const ipBanBackoff = 10 * time.Minute
+
+// Disconnect Cancel Protection window bounds.
+// Documentation: https://bybit-exchange.github.io/docs/v5/order/dcp
+const (
+	minDCPWindow = 3 * time.Second
+	maxDCPWindow = 300 * time.Second
+)
+
+// maxOrderHistoryLimit is the maximum page size for /v5/order/history.
+const maxOrderHistoryLimit = 50
+
+// maxOpenOrdersLimit is the maximum page size for /v5/order/realtime.
+const maxOpenOrdersLimit = 50
+
+// maxExecutionsLimit is the maximum page size for /v5/execution/list.
+const maxExecutionsLimit = 100
+
+// executionsWindow is the longest startTime to endTime span of /v5/execution/list.
+const executionsWindow = 7 * 24 * time.Hour
+
+// Bybit API v5 endpoints
+// Documentation: https://bybit-exchange.github.io/docs/v5/intro
+const (
+	// Market endpoints
+	ETime         = "/v5/market/time"
+	EInstruments  = "/v5/market/instruments-info"
+	ETickers      = "/v5/market/tickers"
+	EOrderbook    = "/v5/market/orderbook"
+	ERecentTrades = "/v5/market/recent-trade"
+	EKline        = "/v5/market/kline"
+
+	// Trade endpoints
+	ECreateOrder     = "/v5/order/create"
+	EAmendOrder      = "/v5/order/amend"
+	ECancelOrder     = "/v5/order/cancel"
+	ECancelAllOrders = "/v5/order/cancel-all"
+	EQueryOrder      = "/v5/order/realtime"
+	EOpenOrders      = "/v5/order/realtime"
+	EOrderHistory    = "/v5/order/history"
+	EExecutions      = "/v5/execution/list"
+
+	// EDisconnectCancelAll configures Disconnect Cancel Protection (DCP)
+	EDisconnectCancelAll = "/v5/order/disconnected-cancel-all"
+
+	// Account endpoints
+	EWalletBalance = "/v5/account/wallet-balance"
+	EAccountInfo   = "/v5/account/info"
+)
+
+// Rate limit categories.
+// Bybit limits requests per second per endpoint group rather than by weight.
+// Documentation: https://bybit-exchange.github.io/docs/v5/rate-limit
+const (
+	RateCategoryOrder   = "order"
+	RateCategoryAccount = "account"
+	RateCategoryQuery   = "query"
+)
+
+// EndpointRateLimits holds the per-second request limits for each category.
+// Last verified: 2026-02-16
+var EndpointRateLimits = map[string]int{
+	RateCategoryOrder:   20, // create, amend, cancel
+	RateCategoryAccount: 10, // wallet balance, account info
+	RateCategoryQuery:   50, // order status, history, market data
+}
+
+// EndpointCategory returns the rate limit category for an endpoint.
+func EndpointCategory(endpoint string) string {
+	switch {
+	case strings.Contains(endpoint, "/v5/order/create"),
+		strings.Contains(endpoint, "/v5/order/amend"),
+		strings.Contains(endpoint, "/v5/order/cancel"):
+		return RateCategoryOrder
+	case strings.Contains(endpoint, "/v5/account/"):
+		return RateCategoryAccount
+	default:
+		return RateCategoryQuery
+	}
+}
+
+// GetEndpointRateLimit returns the per-second limit for an endpoint.
+// Returns 10 as default for unknown categories (conservative).
+func GetEndpointRateLimit(endpoint string) int {
+	if limit, ok := EndpointRateLimits[EndpointCategory(endpoint)]; ok {
+		return limit
+	}
+	return 10
+}
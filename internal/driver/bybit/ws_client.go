@@ -0,0 +1,890 @@
+// Package bybit implements the Bybit exchange driver.
+// WebSocket client with automatic reconnection.
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/connect
+package bybit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/market"
+	"github.com/lilwiggy/ex-act/internal/ratelimit"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+	"github.com/lxzan/gws"
+)
+
+const (
+	exchange = "bybit"
+
+	// maxArgsPerRequest is the maximum number of topics per subscribe request on spot.
+	maxArgsPerRequest = 10
+)
+
+// ReconnectConfig holds reconnection settings.
+type ReconnectConfig struct {
+	InitialDelay time.Duration // Initial reconnection delay (default: 1s)
+	MaxDelay     time.Duration // Maximum reconnection delay (default: 60s)
+	MaxAttempts  int           // Maximum reconnection attempts (0 = infinite)
+	Jitter       float64       // Jitter factor (0-1, default: 0.1)
+}
+
+// DefaultReconnectConfig returns the default reconnection configuration.
+func DefaultReconnectConfig() ReconnectConfig {
+	return ReconnectConfig{
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     60 * time.Second,
+		MaxAttempts:  0, // Infinite
+		Jitter:       0.1,
+	}
+}
+
+// WSConfig holds WebSocket client configuration.
+type WSConfig struct {
+	BaseURL      string          // WebSocket URL (default: public spot or private, by Private flag)
+	Testnet      bool            // Use testnet URLs
+	Private      bool            // Connect to the private stream and authenticate
+	APIKey       string          // API key (required when Private is set)
+	APISecret    string          // API secret (required when Private is set)
+	Gate         *ratelimit.Gate // Backoff gate checked before each dial (default: a new gate)
+	Clock        driver.Clock    // Clock for auth expiry timestamps (default: local time)
+	PingInterval time.Duration   // Heartbeat interval (default: 20s)
+	Reconnect    ReconnectConfig // Reconnection settings
+}
+
+// DefaultWSConfig returns the default WebSocket configuration.
+func DefaultWSConfig() WSConfig {
+	return WSConfig{
+		Testnet:      false,
+		PingInterval: 20 * time.Second,
+		Reconnect:    DefaultReconnectConfig(),
+	}
+}
+
+// Callback functions for different message types.
+type WSClientCallbacks struct {
+	OnTicker     func(ticker *domain.Ticker)
+	OnOrderBook  func(orderBook *domain.OrderBook)
+	OnTrade      func(trade *domain.Trade)
+	OnKline      func(kline *domain.Kline)
+	OnOrder      func(order *domain.Order)
+	OnBalance    func(balance *domain.Balance)
+	OnConnect    func()
+	OnDisconnect func(err error)
+	OnError      func(err error)
+}
+
+// WSClient implements a Bybit v5 WebSocket client with automatic reconnection.
+// Implements gws.EventHandler interface.
+//
+// Key differences from the Binance client:
+//   - Subscriptions are sent as {"op":"subscribe"} requests on the live connection
+//   - Heartbeat is a JSON {"op":"ping"} message, not a WebSocket ping frame
+//   - Order books arrive as snapshot + deltas and are maintained locally
+//   - The private stream authenticates with {"op":"auth"} before subscribing
+type WSClient struct {
+	config        WSConfig
+	testnet       bool // Use testnet URLs
+	callbacks     WSClientCallbacks
+	subscriptions *SubscriptionManager
+	signer        *Signer
+
+	// Local order books keyed by topic
+	books   map[string]*market.Book
+	booksMu sync.Mutex
+
+	// Connection state
+	conn       *gws.Conn
+	connected  atomic.Bool
+	connecting atomic.Bool
+	closed     atomic.Bool
+	connMu     sync.RWMutex
+
+	// Request IDs for op requests
+	reqID atomic.Int64
+
+	// Topics of subscribe requests awaiting a response, keyed by request ID
+	pending   map[string][]string
+	pendingMu sync.Mutex
+
+	// Reconnection state
+	reconnectAttempt int
+	reconnectMu      sync.Mutex
+
+	// Context for cancellation
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Ping ticker
+	pingTicker *time.Ticker
+	pingMu     sync.Mutex
+}
+
+// NewWSClient creates a new WebSocket client.
+func NewWSClient(cfg WSConfig) *WSClient {
+	if cfg.PingInterval == 0 {
+		cfg.PingInterval = 20 * time.Second
+	}
+	if cfg.Reconnect.InitialDelay == 0 {
+		cfg.Reconnect = DefaultReconnectConfig()
+	}
+	if cfg.Gate == nil {
+		cfg.Gate = ratelimit.NewGate()
+	}
+
+	c := &WSClient{
+		config:        cfg,
+		testnet:       cfg.Testnet,
+		subscriptions: NewSubscriptionManager(),
+		books:         make(map[string]*market.Book),
+		pending:       make(map[string][]string),
+	}
+	if cfg.Private {
+		c.signer = NewSigner(cfg.APIKey, cfg.APISecret, 0)
+		if cfg.Clock != nil {
+			c.signer.SetClock(cfg.Clock.UnixMilli)
+		}
+	}
+	return c
+}
+
+// OnTicker sets the ticker callback.
+func (c *WSClient) OnTicker(fn func(ticker *domain.Ticker)) {
+	c.callbacks.OnTicker = fn
+}
+
+// OnOrderBook sets the order book callback.
+func (c *WSClient) OnOrderBook(fn func(orderBook *domain.OrderBook)) {
+	c.callbacks.OnOrderBook = fn
+}
+
+// OnTrade sets the trade callback.
+func (c *WSClient) OnTrade(fn func(trade *domain.Trade)) {
+	c.callbacks.OnTrade = fn
+}
+
+// OnKline sets the kline callback.
+func (c *WSClient) OnKline(fn func(kline *domain.Kline)) {
+	c.callbacks.OnKline = fn
+}
+
+// OnOrder sets the order callback.
+func (c *WSClient) OnOrder(fn func(order *domain.Order)) {
+	c.callbacks.OnOrder = fn
+}
+
+// OnBalance sets the wallet balance callback.
+func (c *WSClient) OnBalance(fn func(balance *domain.Balance)) {
+	c.callbacks.OnBalance = fn
+}
+
+// OnConnect sets the connect callback.
+// For the private stream it fires after authentication succeeds.
+func (c *WSClient) OnConnect(fn func()) {
+	c.callbacks.OnConnect = fn
+}
+
+// OnDisconnect sets the disconnect callback.
+func (c *WSClient) OnDisconnect(fn func(err error)) {
+	c.callbacks.OnDisconnect = fn
+}
+
+// OnError sets the callback for rejected subscriptions.
+func (c *WSClient) OnError(fn func(err error)) {
+	c.callbacks.OnError = fn
+}
+
+// wsURL returns the WebSocket URL based on config.
+func (c *WSClient) wsURL() string {
+	if c.config.BaseURL != "" {
+		return c.config.BaseURL
+	}
+	switch {
+	case c.config.Private && c.testnet:
+		return TestnetPrivateWSURL
+	case c.config.Private:
+		return BasePrivateWSURL
+	case c.testnet:
+		return TestnetPublicSpotWSURL
+	default:
+		return BasePublicSpotWSURL
+	}
+}
+
+// Connect establishes the WebSocket connection.
+// Existing subscriptions are sent once the connection is ready.
+func (c *WSClient) Connect() error {
+	if c.closed.Load() {
+		return errors.NewExchangeError(exchange, "connect", "client is closed", nil)
+	}
+
+	if c.config.Private {
+		if c.signer == nil || c.signer.ValidateCredentials() != nil {
+			return errors.NewExchangeError(exchange, "connect", "API credentials required for private stream", nil)
+		}
+	}
+
+	if c.connecting.Swap(true) {
+		return errors.NewExchangeError(exchange, "connect", "connection already in progress", nil)
+	}
+	defer c.connecting.Store(false)
+
+	// Dialing during a rate limit backoff or IP ban would extend it
+	if err := c.config.Gate.Allow(); err != nil {
+		return err
+	}
+
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	return c.dial()
+}
+
+// dial establishes the WebSocket connection.
+func (c *WSClient) dial() error {
+	c.connMu.Lock()
+
+	url := c.wsURL()
+	option := &gws.ClientOption{
+		Addr: url,
+		TlsConfig: &tls.Config{
+			InsecureSkipVerify: false,
+		},
+	}
+
+	conn, _, err := gws.NewClient(c, option)
+	if err != nil {
+		c.connMu.Unlock()
+		return errors.NewConnectionError(exchange, url, err.Error(), true)
+	}
+
+	c.conn = conn
+	c.connected.Store(true)
+	c.reconnectMu.Lock()
+	c.reconnectAttempt = 0
+	c.reconnectMu.Unlock()
+	c.connMu.Unlock()
+
+	// Books must be rebuilt from a fresh snapshot after reconnect
+	c.booksMu.Lock()
+	c.books = make(map[string]*market.Book)
+	c.booksMu.Unlock()
+
+	// Requests of the previous connection are never answered
+	c.pendingMu.Lock()
+	clear(c.pending)
+	c.pendingMu.Unlock()
+
+	// Start read loop
+	go conn.ReadLoop()
+
+	// Start heartbeat
+	c.startPingTicker()
+
+	if c.config.Private {
+		// Subscriptions and OnConnect follow a successful auth response
+		return c.authenticate()
+	}
+
+	if err := c.sendOp("subscribe", c.subscriptions.Topics()); err != nil {
+		return err
+	}
+
+	c.safeCallback(func() {
+		if c.callbacks.OnConnect != nil {
+			c.callbacks.OnConnect()
+		}
+	})
+
+	return nil
+}
+
+// authenticate sends the auth request for the private stream.
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/connect#authentication
+func (c *WSClient) authenticate() error {
+	expires, signature := c.signer.SignWebSocket()
+	return c.write(WSRequest{
+		ReqID: c.nextReqID(),
+		Op:    "auth",
+		Args:  []any{c.signer.APIKey(), expires, signature},
+	})
+}
+
+// Disconnect closes the WebSocket connection without preventing reconnection.
+func (c *WSClient) Disconnect() error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+
+	c.stopPingTicker()
+	c.connected.Store(false)
+
+	c.conn.WriteClose(1000, nil)
+	c.conn = nil
+
+	return nil
+}
+
+// Close permanently closes the WebSocket client.
+// After Close(), the client cannot be reused.
+func (c *WSClient) Close() error {
+	if c.closed.Swap(true) {
+		return nil // Already closed
+	}
+
+	c.stopPingTicker()
+
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.conn != nil {
+		c.conn.WriteClose(1000, nil)
+		c.conn = nil
+	}
+
+	return nil
+}
+
+// IsConnected returns true if the WebSocket is connected.
+func (c *WSClient) IsConnected() bool {
+	return c.connected.Load()
+}
+
+// Subscribe adds a topic subscription.
+// Bybit supports dynamic subscription, so no reconnect is needed.
+// If not connected, the topic is sent on the next connect.
+func (c *WSClient) Subscribe(topic string) error {
+	if !c.subscriptions.Subscribe(topic) {
+		return nil // Already subscribed
+	}
+
+	if c.connected.Load() {
+		return c.sendOp("subscribe", []string{topic})
+	}
+
+	return nil
+}
+
+// Unsubscribe removes a topic subscription.
+func (c *WSClient) Unsubscribe(topic string) error {
+	if !c.subscriptions.Unsubscribe(topic) {
+		return nil
+	}
+
+	c.booksMu.Lock()
+	delete(c.books, topic)
+	c.booksMu.Unlock()
+
+	if c.connected.Load() {
+		return c.sendOp("unsubscribe", []string{topic})
+	}
+
+	return nil
+}
+
+// sendOp sends a subscribe/unsubscribe request, batching topics per request limit.
+func (c *WSClient) sendOp(op string, topics []string) error {
+	for start := 0; start < len(topics); start += maxArgsPerRequest {
+		end := min(start+maxArgsPerRequest, len(topics))
+
+		args := make([]any, 0, end-start)
+		for _, topic := range topics[start:end] {
+			args = append(args, topic)
+		}
+
+		reqID := c.nextReqID()
+		if op == "subscribe" {
+			c.pendingMu.Lock()
+			c.pending[reqID] = slices.Clone(topics[start:end])
+			c.pendingMu.Unlock()
+		}
+
+		if err := c.write(WSRequest{ReqID: reqID, Op: op, Args: args}); err != nil {
+			c.pendingMu.Lock()
+			delete(c.pending, reqID)
+			c.pendingMu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+// write marshals and sends a request on the current connection.
+func (c *WSClient) write(req WSRequest) error {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	if conn == nil {
+		return errors.NewConnectionError(exchange, c.wsURL(), "not connected", true)
+	}
+
+	if err := conn.WriteMessage(gws.OpcodeText, payload); err != nil {
+		return errors.NewConnectionError(exchange, c.wsURL(), err.Error(), true)
+	}
+	return nil
+}
+
+// nextReqID returns a new request ID.
+func (c *WSClient) nextReqID() string {
+	return strconv.FormatInt(c.reqID.Add(1), 10)
+}
+
+// OnOpen implements gws.EventHandler - called when connection is established.
+func (c *WSClient) OnOpen(socket *gws.Conn) {
+	socket.SetDeadline(time.Now().Add(c.config.PingInterval * 2))
+}
+
+// OnClose implements gws.EventHandler - called when connection is closed.
+func (c *WSClient) OnClose(socket *gws.Conn, err error) {
+	c.connected.Store(false)
+	c.stopPingTicker()
+
+	c.safeCallback(func() {
+		if c.callbacks.OnDisconnect != nil {
+			c.callbacks.OnDisconnect(err)
+		}
+	})
+
+	// Attempt reconnection if not closed intentionally
+	if !c.closed.Load() {
+		go c.reconnect()
+	}
+}
+
+// OnPing implements gws.EventHandler - called when ping is received.
+func (c *WSClient) OnPing(socket *gws.Conn, payload []byte) {
+	socket.SetDeadline(time.Now().Add(c.config.PingInterval * 2))
+	socket.WritePong(payload)
+}
+
+// OnPong implements gws.EventHandler - called when pong is received.
+func (c *WSClient) OnPong(socket *gws.Conn, payload []byte) {
+	socket.SetDeadline(time.Now().Add(c.config.PingInterval * 2))
+}
+
+// OnMessage implements gws.EventHandler - called when a message is received.
+func (c *WSClient) OnMessage(socket *gws.Conn, message *gws.Message) {
+	defer message.Close()
+
+	// Reset deadline on activity (including JSON pongs)
+	socket.SetDeadline(time.Now().Add(c.config.PingInterval * 2))
+
+	data := message.Bytes()
+	if len(data) == 0 {
+		return
+	}
+
+	var wsMsg WSMessage
+	if err := json.Unmarshal(data, &wsMsg); err != nil {
+		return
+	}
+
+	// Messages without a topic are op responses (subscribe, auth, pong)
+	if wsMsg.Topic == "" {
+		c.handleOpResponse(data)
+		return
+	}
+
+	c.routeMessage(&wsMsg)
+}
+
+// routeMessage routes a message to the appropriate handler based on topic.
+func (c *WSClient) routeMessage(msg *WSMessage) {
+	switch ParseTopicType(msg.Topic) {
+	case "tickers":
+		c.handleTicker(msg)
+	case "orderbook":
+		c.handleOrderbook(msg)
+	case "publicTrade":
+		c.handleTrade(msg)
+	case "kline":
+		c.handleKline(msg)
+	case TopicOrder:
+		c.handleOrderUpdate(msg)
+	case TopicWallet:
+		c.handleWallet(msg)
+	}
+}
+
+// handleOpResponse handles responses to op requests.
+func (c *WSClient) handleOpResponse(data []byte) {
+	var resp WSOpResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return
+	}
+
+	switch resp.Op {
+	case "auth":
+		if !resp.Success {
+			// Auth failures are not recoverable by retrying the same credentials
+			c.safeCallback(func() {
+				if c.callbacks.OnDisconnect != nil {
+					c.callbacks.OnDisconnect(errors.NewSignatureError(exchange, "auth", resp.RetMsg))
+				}
+			})
+			return
+		}
+
+		_ = c.sendOp("subscribe", c.subscriptions.Topics())
+
+		c.safeCallback(func() {
+			if c.callbacks.OnConnect != nil {
+				c.callbacks.OnConnect()
+			}
+		})
+	case "subscribe":
+		c.handleSubscribeResponse(&resp)
+	case "ping", "pong":
+		// Heartbeat response - deadline already extended
+	}
+}
+
+// handleSubscribeResponse drops the topics of a rejected subscribe request,
+// so they are not sent again on reconnect, and reports the rejection.
+// Example: {"success":false,"ret_msg":"error:handler not found,topic:orderbook.40.BTCUSDT","op":"subscribe","req_id":"3"}
+func (c *WSClient) handleSubscribeResponse(resp *WSOpResponse) {
+	c.pendingMu.Lock()
+	topics := c.pending[resp.ReqID]
+	delete(c.pending, resp.ReqID)
+	c.pendingMu.Unlock()
+
+	if resp.Success {
+		return
+	}
+
+	// Bybit names the invalid topic; otherwise the whole request is rejected
+	rejected := slices.DeleteFunc(slices.Clone(topics), func(topic string) bool {
+		return !strings.Contains(resp.RetMsg, topic)
+	})
+	if len(rejected) == 0 {
+		rejected = topics
+	}
+	for _, topic := range rejected {
+		c.subscriptions.Unsubscribe(topic)
+	}
+
+	msg := resp.RetMsg
+	if len(rejected) > 0 {
+		msg = "rejected " + strings.Join(rejected, ",") + ": " + msg
+	}
+	err := errors.NewExchangeError(exchange, "subscribe", msg, nil)
+	c.safeCallback(func() {
+		if c.callbacks.OnError != nil {
+			c.callbacks.OnError(err)
+		}
+	})
+}
+
+// handleTicker handles ticker messages.
+func (c *WSClient) handleTicker(msg *WSMessage) {
+	if c.callbacks.OnTicker == nil {
+		return
+	}
+
+	var ticker WSTicker
+	if err := json.Unmarshal(msg.Data, &ticker); err != nil {
+		return
+	}
+
+	domainTicker, err := ticker.ToDomain(exchange, msg.TS)
+	if err != nil {
+		return
+	}
+
+	c.safeCallback(func() {
+		c.callbacks.OnTicker(domainTicker)
+	})
+}
+
+// handleOrderbook handles order book snapshots and deltas.
+// The local book is reset on snapshot and updated on delta, then the full
+// book is delivered so consumers always see consistent state.
+func (c *WSClient) handleOrderbook(msg *WSMessage) {
+	if c.callbacks.OnOrderBook == nil {
+		return
+	}
+
+	var data WSOrderbookData
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return
+	}
+
+	bids, asks, err := data.ToDomain()
+	if err != nil {
+		return
+	}
+
+	c.booksMu.Lock()
+	book, ok := c.books[msg.Topic]
+	if msg.Type == "snapshot" || !ok {
+		if msg.Type != "snapshot" {
+			// Delta without a snapshot - wait for the next snapshot
+			c.booksMu.Unlock()
+			return
+		}
+		book = market.NewBook(exchange, symbols.Normalize(data.Symbol))
+		c.books[msg.Topic] = book
+	}
+	if msg.Type == "snapshot" {
+		book.Reset(bids, asks, data.UpdateID, time.UnixMilli(msg.TS))
+	} else {
+		book.Apply(bids, asks, data.UpdateID, time.UnixMilli(msg.TS))
+	}
+	orderBook := book.Snapshot()
+	c.booksMu.Unlock()
+
+	c.safeCallback(func() {
+		c.callbacks.OnOrderBook(orderBook)
+	})
+}
+
+// handleTrade handles public trade messages.
+// Each message carries a batch of trades.
+func (c *WSClient) handleTrade(msg *WSMessage) {
+	if c.callbacks.OnTrade == nil {
+		return
+	}
+
+	var trades []WSTradeData
+	if err := json.Unmarshal(msg.Data, &trades); err != nil {
+		return
+	}
+
+	for i := range trades {
+		domainTrade, err := trades[i].ToDomain(exchange)
+		if err != nil {
+			continue
+		}
+
+		c.safeCallback(func() {
+			c.callbacks.OnTrade(domainTrade)
+		})
+	}
+}
+
+// handleKline handles kline updates.
+func (c *WSClient) handleKline(msg *WSMessage) {
+	if c.callbacks.OnKline == nil {
+		return
+	}
+
+	var klines []WSKline
+	if err := json.Unmarshal(msg.Data, &klines); err != nil {
+		return
+	}
+
+	symbol := ParseTopicSymbol(msg.Topic)
+	for i := range klines {
+		domainKline, err := klines[i].ToDomain(exchange, symbol)
+		if err != nil {
+			continue
+		}
+
+		c.safeCallback(func() {
+			c.callbacks.OnKline(domainKline)
+		})
+	}
+}
+
+// handleOrderUpdate handles private order updates.
+func (c *WSClient) handleOrderUpdate(msg *WSMessage) {
+	if c.callbacks.OnOrder == nil {
+		return
+	}
+
+	var updates []WSOrderUpdate
+	if err := json.Unmarshal(msg.Data, &updates); err != nil {
+		return
+	}
+
+	for i := range updates {
+		// The private stream also carries linear/inverse orders on unified accounts
+		if updates[i].Category != "" && updates[i].Category != CategorySpot {
+			continue
+		}
+
+		domainOrder, err := updates[i].ToDomain(exchange)
+		if err != nil {
+			continue
+		}
+
+		c.safeCallback(func() {
+			c.callbacks.OnOrder(domainOrder)
+		})
+	}
+}
+
+// handleWallet handles private wallet updates.
+// Each update carries the full state of every coin that changed.
+func (c *WSClient) handleWallet(msg *WSMessage) {
+	if c.callbacks.OnBalance == nil {
+		return
+	}
+
+	var accounts []AccountInfo
+	if err := json.Unmarshal(msg.Data, &accounts); err != nil {
+		return
+	}
+
+	updated := time.UnixMilli(msg.CreationTime)
+	for i := range accounts {
+		for j := range accounts[i].Coins {
+			balance, err := accounts[i].Coins[j].ToDomain()
+			if err != nil {
+				continue
+			}
+			if msg.CreationTime > 0 {
+				balance.Timestamp = updated
+			}
+
+			c.safeCallback(func() {
+				c.callbacks.OnBalance(balance)
+			})
+		}
+	}
+}
+
+// safeCallback executes a callback with panic recovery.
+// CRITICAL: Callbacks MUST be wrapped in panic recovery.
+func (c *WSClient) safeCallback(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			// Log panic but don't crash the client
+		}
+	}()
+	fn()
+}
+
+// startPingTicker starts the JSON heartbeat.
+// CRITICAL: Bybit drops connections without a {"op":"ping"} every 20 seconds.
+func (c *WSClient) startPingTicker() {
+	c.pingMu.Lock()
+	defer c.pingMu.Unlock()
+
+	if c.pingTicker != nil {
+		c.pingTicker.Stop()
+	}
+
+	c.pingTicker = time.NewTicker(c.config.PingInterval)
+	go func(ticker *time.Ticker) {
+		for range ticker.C {
+			if c.connected.Load() {
+				_ = c.write(WSRequest{ReqID: c.nextReqID(), Op: "ping"})
+			}
+		}
+	}(c.pingTicker)
+}
+
+// stopPingTicker stops the heartbeat.
+func (c *WSClient) stopPingTicker() {
+	c.pingMu.Lock()
+	defer c.pingMu.Unlock()
+
+	if c.pingTicker != nil {
+		c.pingTicker.Stop()
+		c.pingTicker = nil
+	}
+}
+
+// reconnect handles reconnection with exponential backoff.
+// Subscriptions are replayed by dial once the connection is ready.
+func (c *WSClient) reconnect() error {
+	// Prevent multiple reconnect attempts
+	c.reconnectMu.Lock()
+	if c.reconnectAttempt > 0 {
+		c.reconnectMu.Unlock()
+		return nil // Reconnection already in progress
+	}
+	c.reconnectMu.Unlock()
+
+	if c.closed.Load() {
+		return errors.NewExchangeError(exchange, "reconnect", "client is closed", nil)
+	}
+
+	_ = c.Disconnect()
+
+	for {
+		if c.closed.Load() || (c.ctx != nil && c.ctx.Err() != nil) {
+			return errors.NewExchangeError(exchange, "reconnect", "client closed or context cancelled", nil)
+		}
+
+		c.reconnectMu.Lock()
+		c.reconnectAttempt++
+		attempt := c.reconnectAttempt
+		c.reconnectMu.Unlock()
+
+		if c.config.Reconnect.MaxAttempts > 0 && attempt > c.config.Reconnect.MaxAttempts {
+			return errors.NewWebSocketReconnectError(
+				exchange,
+				"",
+				"max reconnection attempts exceeded",
+				attempt,
+				c.config.Reconnect.MaxAttempts,
+			)
+		}
+
+		delay := c.calculateBackoff(attempt)
+		time.Sleep(delay)
+
+		// Hold reconnects until any rate limit backoff or IP ban lifts
+		if err := c.config.Gate.Wait(c.ctx); err != nil {
+			continue
+		}
+
+		if err := c.dial(); err != nil {
+			continue
+		}
+
+		c.reconnectMu.Lock()
+		c.reconnectAttempt = 0
+		c.reconnectMu.Unlock()
+
+		return nil
+	}
+}
+
+// calculateBackoff calculates the reconnection delay for an attempt.
+func (c *WSClient) calculateBackoff(attempt int) time.Duration {
+	return reconnectDelay(c.config.Reconnect, attempt)
+}
+
+// reconnectDelay calculates the reconnection delay with exponential backoff and jitter.
+// Formula: delay = min(initialDelay * 2^attempt, maxDelay) * (1 + random * jitter)
+func reconnectDelay(cfg ReconnectConfig, attempt int) time.Duration {
+	delay := cfg.InitialDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+			break
+		}
+	}
+
+	if cfg.Jitter > 0 {
+		jitter := time.Duration(float64(delay) * cfg.Jitter * (rand.Float64()*2 - 1))
+		delay += jitter
+	}
+
+	return delay
+}
@@ -0,0 +1,522 @@
+// Package bybit implements the Bybit exchange driver.
+// WebSocket message types for Bybit v5 streams.
+// API Documentation: https://bybit-exchange.github.io/docs/v5/ws/connect
+// API Version: v5 (verified 2026-02-16)
+package bybit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+)
+
+// WSMessage is the base wrapper for topic messages.
+// Public format: {"topic":"tickers.BTCUSDT","type":"snapshot","ts":1672304486868,"data":{...}}
+// Private format: {"topic":"order","id":"...","creationTime":1672364262474,"data":[...]}
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/public/ticker
+type WSMessage struct {
+	Topic        string          `json:"topic"`
+	Type         string          `json:"type"` // snapshot or delta (public only)
+	TS           int64           `json:"ts"`
+	CreationTime int64           `json:"creationTime"` // private only
+	Data         json.RawMessage `json:"data"`
+}
+
+// WSOpResponse is the response to an op request (subscribe, auth, ping).
+// Example: {"success":true,"ret_msg":"","op":"subscribe","conn_id":"cejreaspqfh3sjdnldmg-p","req_id":"1"}
+type WSOpResponse struct {
+	Success bool   `json:"success"`
+	RetMsg  string `json:"ret_msg"`
+	Op      string `json:"op"`
+	ConnID  string `json:"conn_id"`
+	ReqID   string `json:"req_id"`
+}
+
+// WSRequest is an op request sent to the server.
+// Examples:
+//
+//	{"req_id":"1","op":"subscribe","args":["tickers.BTCUSDT"]}
+//	{"op":"auth","args":["api_key",1662350400000,"signature"]}
+//	{"op":"ping"}
+type WSRequest struct {
+	ReqID string `json:"req_id,omitempty"`
+	Op    string `json:"op"`
+	Args  []any  `json:"args,omitempty"`
+}
+
+// WSTicker represents a spot ticker update.
+// WebSocket Topic: tickers.{symbol}
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/public/ticker
+// All price/quantity fields are strings in Bybit JSON - MUST parse to Decimal.
+type WSTicker struct {
+	Symbol        string `json:"symbol"`
+	LastPrice     string `json:"lastPrice"`
+	HighPrice24h  string `json:"highPrice24h"`
+	LowPrice24h   string `json:"lowPrice24h"`
+	PrevPrice24h  string `json:"prevPrice24h"`
+	Volume24h     string `json:"volume24h"`
+	Turnover24h   string `json:"turnover24h"`
+	Price24hPcnt  string `json:"price24hPcnt"` // Fraction, e.g. "0.0123" = 1.23%
+	Bid1Price     string `json:"bid1Price"`    // Not sent on every spot ticker
+	Bid1Size      string `json:"bid1Size"`
+	Ask1Price     string `json:"ask1Price"`
+	Ask1Size      string `json:"ask1Size"`
+	USDIndexPrice string `json:"usdIndexPrice"`
+}
+
+// ToDomain converts WSTicker to domain.Ticker.
+// ts is the message timestamp in milliseconds.
+func (t *WSTicker) ToDomain(exchange string, ts int64) (*domain.Ticker, error) {
+	symbol := symbols.Normalize(t.Symbol)
+
+	lastPrice, err := domain.NewDecimal(t.LastPrice)
+	if err != nil {
+		return nil, fmt.Errorf("parse last_price: %w", err)
+	}
+
+	highPrice, err := domain.NewDecimal(t.HighPrice24h)
+	if err != nil {
+		return nil, fmt.Errorf("parse high_price: %w", err)
+	}
+
+	lowPrice, err := domain.NewDecimal(t.LowPrice24h)
+	if err != nil {
+		return nil, fmt.Errorf("parse low_price: %w", err)
+	}
+
+	openPrice, err := domain.NewDecimal(t.PrevPrice24h)
+	if err != nil {
+		return nil, fmt.Errorf("parse open_price: %w", err)
+	}
+
+	volume, err := domain.NewDecimal(t.Volume24h)
+	if err != nil {
+		return nil, fmt.Errorf("parse volume: %w", err)
+	}
+
+	quoteVolume, err := domain.NewDecimal(t.Turnover24h)
+	if err != nil {
+		return nil, fmt.Errorf("parse quote_volume: %w", err)
+	}
+
+	changeFraction, err := domain.NewDecimal(t.Price24hPcnt)
+	if err != nil {
+		return nil, fmt.Errorf("parse price_change_percent: %w", err)
+	}
+
+	bidPrice, err := parseOptionalDecimal(t.Bid1Price)
+	if err != nil {
+		return nil, fmt.Errorf("parse bid_price: %w", err)
+	}
+
+	bidQty, err := parseOptionalDecimal(t.Bid1Size)
+	if err != nil {
+		return nil, fmt.Errorf("parse bid_quantity: %w", err)
+	}
+
+	askPrice, err := parseOptionalDecimal(t.Ask1Price)
+	if err != nil {
+		return nil, fmt.Errorf("parse ask_price: %w", err)
+	}
+
+	askQty, err := parseOptionalDecimal(t.Ask1Size)
+	if err != nil {
+		return nil, fmt.Errorf("parse ask_quantity: %w", err)
+	}
+
+	return &domain.Ticker{
+		Exchange:           exchange,
+		Symbol:             symbol,
+		BidPrice:           bidPrice,
+		BidQuantity:        bidQty,
+		AskPrice:           askPrice,
+		AskQuantity:        askQty,
+		LastPrice:          lastPrice,
+		HighPrice:          highPrice,
+		LowPrice:           lowPrice,
+		Volume:             volume,
+		QuoteVolume:        quoteVolume,
+		PriceChange:        domain.Sub(lastPrice, openPrice),
+		PriceChangePercent: domain.Mul(changeFraction, domain.NewDecimalFromInt(100)),
+		OpenPrice:          openPrice,
+		Timestamp:          time.UnixMilli(ts),
+	}, nil
+}
+
+// WSOrderbookData represents an order book snapshot or delta.
+// WebSocket Topic: orderbook.{depth}.{symbol}
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/public/orderbook
+// IMPORTANT: The first message is a snapshot, following messages are deltas.
+// A delta level with size "0" removes the price level.
+type WSOrderbookData struct {
+	Symbol   string     `json:"s"`   // Symbol
+	Bids     [][]string `json:"b"`   // Bids [[price, size], ...]
+	Asks     [][]string `json:"a"`   // Asks [[price, size], ...]
+	UpdateID int64      `json:"u"`   // Update ID (1 after a service restart)
+	Seq      int64      `json:"seq"` // Cross sequence
+}
+
+// ToDomain converts WSOrderbookData to bid and ask slices.
+func (d *WSOrderbookData) ToDomain() (bids, asks []domain.OrderBookLevel, err error) {
+	bids, err = parseLevels(d.Bids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse bids: %w", err)
+	}
+	asks, err = parseLevels(d.Asks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse asks: %w", err)
+	}
+	return bids, asks, nil
+}
+
+// WSTradeData represents a single public trade.
+// WebSocket Topic: publicTrade.{symbol}
+// Documentation: https://bybit-exchange.github.io/docs/v5/ws/public/trade
+type WSTradeData struct {
+	Timestamp  int64  `json:"T"`  // Trade time (milliseconds)
+	Symbol     string `json:"s"`  // Symbol
+	Side       string `json:"S"`  // Taker side (Buy or Sell)
+	Size       string `json:"v"`  // Trade size
+	Price      string `json:"p"`  // Trade price
+	TickDir    string `json:"L"`  // Direction of price change
+	TradeID    string `json:"i"`  // Trade ID
+	BlockTrade bool   `json:"BT"` // Is block trade
+}
+
+// ToDomain converts WSTradeData to domain.Trade.
+func (t *WSTradeData) ToDomain(exchange string) (*domain.Trade, error) {
+	symbol := symbols.Normalize(t.Symbol)
+
+	price, err := domain.NewDecimal(t.Price)
+	if err != nil {
+		return nil, fmt.Errorf("parse price: %w", err)
+	}
+
+	qty, err := domain.NewDecimal(t.Size)
+	if err != nil {
+		return nil, fmt.Errorf("parse quantity: %w", err)
+	}
+
+	side, err := parseSide(t.Side)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Trade{
+		Exchange:      exchange,
+		Symbol:        symbol,
+		ID:            t.TradeID,
+		Price:         price,
+		Quantity:      qty,
+		QuoteQuantity: domain.Mul(price, qty),
+		Side:          side,
+		IsMaker:       side == domain.OrderSideSell, // Buyer is maker, as on Binance
+		Timestamp:     time.UnixMilli(t.Timestamp),
+	}, nil
+}
+
+// WSKline represents a kline update.
+// WebSocket Topic: kline.<interval>.<symbol>
+// Documentation: https://bybit-exchange.github.io/docs/v5/websocket/public/kline
+type WSKline struct {
+	Start     int64  `json:"start"`     // Kline start time (milliseconds)
+	End       int64  `json:"end"`       // Kline end time (milliseconds)
+	Interval  string `json:"interval"`  // Bybit interval (e.g., "1", "60", "D")
+	Open      string `json:"open"`      // Open price
+	Close     string `json:"close"`     // Close price
+	High      string `json:"high"`      // High price
+	Low       string `json:"low"`       // Low price
+	Volume    string `json:"volume"`    // Base asset volume
+	Turnover  string `json:"turnover"`  // Quote asset volume
+	Confirm   bool   `json:"confirm"`   // Whether the kline is closed
+	Timestamp int64  `json:"timestamp"` // Last matched order time
+}
+
+// ToDomain converts WSKline to domain.Kline.
+// The symbol comes from the topic; kline data does not carry it.
+func (k *WSKline) ToDomain(exchange, symbol string) (*domain.Kline, error) {
+	open, err := domain.NewDecimal(k.Open)
+	if err != nil {
+		return nil, fmt.Errorf("parse open: %w", err)
+	}
+
+	high, err := domain.NewDecimal(k.High)
+	if err != nil {
+		return nil, fmt.Errorf("parse high: %w", err)
+	}
+
+	low, err := domain.NewDecimal(k.Low)
+	if err != nil {
+		return nil, fmt.Errorf("parse low: %w", err)
+	}
+
+	close, err := domain.NewDecimal(k.Close)
+	if err != nil {
+		return nil, fmt.Errorf("parse close: %w", err)
+	}
+
+	volume, err := domain.NewDecimal(k.Volume)
+	if err != nil {
+		return nil, fmt.Errorf("parse volume: %w", err)
+	}
+
+	turnover, err := domain.NewDecimal(k.Turnover)
+	if err != nil {
+		return nil, fmt.Errorf("parse turnover: %w", err)
+	}
+
+	return &domain.Kline{
+		Exchange:            exchange,
+		Symbol:              symbols.Normalize(symbol),
+		Interval:            normalizeInterval(k.Interval),
+		OpenTime:            time.UnixMilli(k.Start),
+		CloseTime:           time.UnixMilli(k.End),
+		Open:                open,
+		High:                high,
+		Low:                 low,
+		Close:               close,
+		Volume:              volume,
+		QuoteVolume:         turnover,
+		TakerBuyVolume:      domain.Zero(),
+		TakerBuyQuoteVolume: domain.Zero(),
+		IsClosed:            k.Confirm,
+	}, nil
+}
+
+// WSOrderUpdate represents an order update from the private order topic.
+// The same shape is returned by GET /v5/order/realtime and /v5/order/history.
+// WebSocket Topic: order
+// Documentation: https://bybit-exchange.github.io/docs/v5/websocket/private/order
+type WSOrderUpdate struct {
+	Category     string `json:"category"`
+	OrderID      string `json:"orderId"`
+	OrderLinkID  string `json:"orderLinkId"`
+	Symbol       string `json:"symbol"`
+	Side         string `json:"side"`        // Buy or Sell
+	OrderType    string `json:"orderType"`   // Limit or Market
+	TimeInForce  string `json:"timeInForce"` // GTC, IOC, FOK, PostOnly
+	Price        string `json:"price"`
+	Qty          string `json:"qty"`
+	OrderStatus  string `json:"orderStatus"`
+	AvgPrice     string `json:"avgPrice"`
+	CumExecQty   string `json:"cumExecQty"`
+	CumExecValue string `json:"cumExecValue"`
+	CumExecFee   string `json:"cumExecFee"`
+	FeeCurrency  string `json:"feeCurrency"`
+	RejectReason string `json:"rejectReason"`
+	CreatedTime  string `json:"createdTime"` // Milliseconds as string
+	UpdatedTime  string `json:"updatedTime"` // Milliseconds as string
+}
+
+// ToDomain converts WSOrderUpdate to domain.Order.
+func (o *WSOrderUpdate) ToDomain(exchange string) (*domain.Order, error) {
+	symbol := symbols.Normalize(o.Symbol)
+
+	side, err := parseSide(o.Side)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderType domain.OrderType
+	switch o.OrderType {
+	case "Limit":
+		orderType = domain.OrderTypeLimit
+	case "Market":
+		orderType = domain.OrderTypeMarket
+	default:
+		orderType = domain.OrderType(o.OrderType)
+	}
+
+	status := parseOrderStatus(o.OrderStatus)
+
+	price, err := parseOptionalDecimal(o.Price)
+	if err != nil {
+		return nil, fmt.Errorf("parse price: %w", err)
+	}
+
+	qty, err := domain.NewDecimal(o.Qty)
+	if err != nil {
+		return nil, fmt.Errorf("parse qty: %w", err)
+	}
+
+	filledQty, err := parseOptionalDecimal(o.CumExecQty)
+	if err != nil {
+		return nil, fmt.Errorf("parse cum_exec_qty: %w", err)
+	}
+
+	quoteQty, err := parseOptionalDecimal(o.CumExecValue)
+	if err != nil {
+		return nil, fmt.Errorf("parse cum_exec_value: %w", err)
+	}
+
+	commission, err := parseOptionalDecimal(o.CumExecFee)
+	if err != nil {
+		return nil, fmt.Errorf("parse cum_exec_fee: %w", err)
+	}
+
+	return &domain.Order{
+		Exchange:        exchange,
+		Symbol:          symbol,
+		ID:              o.OrderID,
+		ClientOrderID:   o.OrderLinkID,
+		Side:            side,
+		Type:            orderType,
+		Status:          status,
+		Price:           price,
+		Quantity:        qty,
+		FilledQuantity:  filledQty,
+		QuoteQuantity:   quoteQty,
+		Commission:      commission,
+		CommissionAsset: o.FeeCurrency,
+		CreatedAt:       parseMillis(o.CreatedTime),
+		UpdatedAt:       parseMillis(o.UpdatedTime),
+		IsWorking:       !status.IsFinal(),
+	}, nil
+}
+
+// parseSide converts a Bybit side ("Buy"/"Sell") to domain.OrderSide.
+func parseSide(side string) (domain.OrderSide, error) {
+	switch side {
+	case "Buy":
+		return domain.OrderSideBuy, nil
+	case "Sell":
+		return domain.OrderSideSell, nil
+	default:
+		return "", fmt.Errorf("invalid order side: %s", side)
+	}
+}
+
+// ExecTypeTrade is the execution type of a fill.
+const ExecTypeTrade = "Trade"
+
+// WSExecution represents a fill from the private execution topic.
+// The same shape is returned by GET /v5/execution/list.
+// WebSocket Topic: execution
+// Documentation: https://bybit-exchange.github.io/docs/v5/websocket/private/execution
+type WSExecution struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+	Side        string `json:"side"` // Buy or Sell
+	ExecID      string `json:"execId"`
+	ExecType    string `json:"execType"` // Trade, AdlTrade, Funding, BustTrade, Settle
+	ExecPrice   string `json:"execPrice"`
+	ExecQty     string `json:"execQty"`
+	ExecValue   string `json:"execValue"`
+	ExecFee     string `json:"execFee"`
+	FeeCurrency string `json:"feeCurrency"` // Spot only
+	ExecTime    string `json:"execTime"`
+	IsMaker     bool   `json:"isMaker"`
+}
+
+// ToDomain converts WSExecution to domain.Trade.
+func (e *WSExecution) ToDomain(exchange string) (*domain.Trade, error) {
+	side, err := parseSide(e.Side)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := domain.NewDecimal(e.ExecPrice)
+	if err != nil {
+		return nil, fmt.Errorf("parse exec_price: %w", err)
+	}
+
+	qty, err := domain.NewDecimal(e.ExecQty)
+	if err != nil {
+		return nil, fmt.Errorf("parse exec_qty: %w", err)
+	}
+
+	value, err := parseOptionalDecimal(e.ExecValue)
+	if err != nil {
+		return nil, fmt.Errorf("parse exec_value: %w", err)
+	}
+
+	fee, err := parseOptionalDecimal(e.ExecFee)
+	if err != nil {
+		return nil, fmt.Errorf("parse exec_fee: %w", err)
+	}
+
+	return &domain.Trade{
+		Exchange:        exchange,
+		Symbol:          symbols.Normalize(e.Symbol),
+		ID:              e.ExecID,
+		OrderID:         e.OrderID,
+		Price:           price,
+		Quantity:        qty,
+		QuoteQuantity:   value,
+		Commission:      fee,
+		CommissionAsset: e.FeeCurrency,
+		Side:            side,
+		IsMaker:         e.IsMaker,
+		Timestamp:       parseMillis(e.ExecTime),
+	}, nil
+}
+
+// formatSide converts domain.OrderSide to Bybit format ("Buy"/"Sell").
+func formatSide(side domain.OrderSide) string {
+	if side == domain.OrderSideSell {
+		return "Sell"
+	}
+	return "Buy"
+}
+
+// parseOrderStatus converts a Bybit order status to domain.OrderStatus.
+// Documentation: https://bybit-exchange.github.io/docs/v5/enum#orderstatus
+func parseOrderStatus(status string) domain.OrderStatus {
+	switch status {
+	case "New", "Untriggered", "Triggered":
+		return domain.OrderStatusNew
+	case "PartiallyFilled":
+		return domain.OrderStatusPartiallyFilled
+	case "Filled":
+		return domain.OrderStatusFilled
+	case "Cancelled", "PartiallyFilledCanceled", "Deactivated":
+		return domain.OrderStatusCanceled
+	case "Rejected":
+		return domain.OrderStatusRejected
+	default:
+		return domain.OrderStatus(status)
+	}
+}
+
+// parseLevels converts [[price, size], ...] into order book levels.
+func parseLevels(raw [][]string) ([]domain.OrderBookLevel, error) {
+	levels := make([]domain.OrderBookLevel, 0, len(raw))
+	for _, level := range raw {
+		if len(level) < 2 {
+			continue
+		}
+		price, err := domain.NewDecimal(level[0])
+		if err != nil {
+			return nil, fmt.Errorf("parse price: %w", err)
+		}
+		qty, err := domain.NewDecimal(level[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse quantity: %w", err)
+		}
+		levels = append(levels, domain.OrderBookLevel{Price: price, Quantity: qty})
+	}
+	return levels, nil
+}
+
+// parseOptionalDecimal parses s, returning zero for an empty string.
+// Bybit sends "" for fields that do not apply (e.g. price of a market order).
+func parseOptionalDecimal(s string) (domain.Decimal, error) {
+	if s == "" {
+		return domain.Zero(), nil
+	}
+	return domain.NewDecimal(s)
+}
+
+// parseMillis parses a millisecond timestamp sent as a string.
+func parseMillis(s string) time.Time {
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
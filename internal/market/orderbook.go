@@ -0,0 +1,142 @@
+// Package market provides exchange-agnostic market data structures.
+package market
+
+import (
+	"sort"
+	stdsync "sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+)
+
+// Book is a locally maintained order book for one symbol.
+// Levels are kept sorted best-first so snapshots and top-N views are cheap.
+// All methods are safe for concurrent use.
+type Book struct {
+	exchange string
+	symbol   string
+
+	mu           stdsync.RWMutex
+	bids         []domain.OrderBookLevel // Price descending
+	asks         []domain.OrderBookLevel // Price ascending
+	lastUpdateID int64
+	updated      time.Time
+}
+
+// NewBook creates an empty book.
+// symbol should be in normalized format (e.g., "BTC/USDT").
+func NewBook(exchange, symbol string) *Book {
+	return &Book{
+		exchange: exchange,
+		symbol:   symbol,
+	}
+}
+
+// Reset replaces the book contents with a snapshot.
+// Zero-quantity levels are ignored.
+func (b *Book) Reset(bids, asks []domain.OrderBookLevel, lastUpdateID int64, ts time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	for _, level := range bids {
+		b.bids = applyLevel(b.bids, level, true)
+	}
+	for _, level := range asks {
+		b.asks = applyLevel(b.asks, level, false)
+	}
+	b.lastUpdateID = lastUpdateID
+	b.updated = ts
+}
+
+// Apply merges a diff into the book; a zero quantity removes the level.
+func (b *Book) Apply(bids, asks []domain.OrderBookLevel, lastUpdateID int64, ts time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, level := range bids {
+		b.bids = applyLevel(b.bids, level, true)
+	}
+	for _, level := range asks {
+		b.asks = applyLevel(b.asks, level, false)
+	}
+	b.lastUpdateID = lastUpdateID
+	b.updated = ts
+}
+
+// Clear removes all levels.
+func (b *Book) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	b.lastUpdateID = 0
+	b.updated = time.Time{}
+}
+
+// LastUpdateID returns the update ID of the last snapshot or diff applied.
+func (b *Book) LastUpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastUpdateID
+}
+
+// Snapshot returns a consistent copy of the full book.
+func (b *Book) Snapshot() *domain.OrderBook {
+	return b.Top(0)
+}
+
+// Top returns a consistent copy of the best depth levels per side.
+// A depth of zero or less returns every level.
+func (b *Book) Top(depth int) *domain.OrderBook {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return &domain.OrderBook{
+		Exchange:     b.exchange,
+		Symbol:       b.symbol,
+		Bids:         copyLevels(b.bids, depth),
+		Asks:         copyLevels(b.asks, depth),
+		LastUpdateID: b.lastUpdateID,
+		Timestamp:    b.updated,
+	}
+}
+
+// applyLevel inserts, replaces or removes a level in a sorted side.
+func applyLevel(side []domain.OrderBookLevel, level domain.OrderBookLevel, descending bool) []domain.OrderBookLevel {
+	i := sort.Search(len(side), func(i int) bool {
+		if descending {
+			return domain.Cmp(side[i].Price, level.Price) <= 0
+		}
+		return domain.Cmp(side[i].Price, level.Price) >= 0
+	})
+	found := i < len(side) && domain.Cmp(side[i].Price, level.Price) == 0
+
+	switch {
+	case domain.IsZero(level.Quantity):
+		if found {
+			side = append(side[:i], side[i+1:]...)
+		}
+	case found:
+		side[i] = level
+	default:
+		side = append(side, domain.OrderBookLevel{})
+		copy(side[i+1:], side[i:])
+		side[i] = level
+	}
+	return side
+}
+
+// copyLevels copies up to depth levels; zero or less copies all.
+// Level values are immutable once applied, so decimals are shared.
+func copyLevels(side []domain.OrderBookLevel, depth int) []domain.OrderBookLevel {
+	n := len(side)
+	if depth > 0 {
+		n = min(n, depth)
+	}
+	out := make([]domain.OrderBookLevel, n)
+	copy(out, side[:n])
+	return out
+}
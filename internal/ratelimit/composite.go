@@ -0,0 +1,266 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Rate limit types reported in exchangeInfo rateLimits.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#limits
+const (
+	LimitRequestWeight = "REQUEST_WEIGHT" // Weight per IP
+	LimitOrders        = "ORDERS"         // New orders per account
+	LimitRawRequests   = "RAW_REQUESTS"   // Requests per IP, regardless of weight
+)
+
+// Limit is a single rate limit bucket: at most Limit units per Interval.
+type Limit struct {
+	Type     string        // LimitRequestWeight, LimitOrders or LimitRawRequests
+	Interval time.Duration // Window length
+	Limit    int           // Units allowed per window
+}
+
+// DefaultLimits are the Binance spot limits used until exchangeInfo is loaded.
+// Last verified: 2026-02-16
+var DefaultLimits = []Limit{
+	{Type: LimitRequestWeight, Interval: time.Minute, Limit: DefaultMaxWeight},
+	{Type: LimitOrders, Interval: 10 * time.Second, Limit: 50},
+	{Type: LimitOrders, Interval: 24 * time.Hour, Limit: 160000},
+	{Type: LimitRawRequests, Interval: 5 * time.Minute, Limit: 61000},
+}
+
+// Cost is what a single request consumes.
+// Every request also counts once against RAW_REQUESTS buckets.
+type Cost struct {
+	Weight int // REQUEST_WEIGHT units
+	Orders int // ORDERS units (order placement only)
+}
+
+// units returns the cost for a bucket type.
+func (c Cost) units(limitType string) int {
+	switch limitType {
+	case LimitRequestWeight:
+		return c.Weight
+	case LimitOrders:
+		return c.Orders
+	case LimitRawRequests:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// BucketStats describes the state of one bucket.
+type BucketStats struct {
+	Type      string        `json:"type"`
+	Interval  time.Duration `json:"interval"`
+	Limit     int           `json:"limit"`
+	Used      int           `json:"used"`
+	Remaining int           `json:"remaining"`
+	ResetsAt  time.Time     `json:"resets_at"`
+}
+
+// bucket is a fixed-window counter.
+// Windows are aligned to multiples of the interval (e.g., minute and UTC day
+// boundaries), matching how the exchange counts usage.
+type bucket struct {
+	limit  Limit
+	used   int
+	window time.Time // Start of the current window
+}
+
+// roll starts a new window if the current one has ended.
+func (b *bucket) roll(now time.Time) {
+	if start := now.Truncate(b.limit.Interval); start.After(b.window) {
+		b.window = start
+		b.used = 0
+	}
+}
+
+// resetsAt returns the end of the current window.
+func (b *bucket) resetsAt() time.Time {
+	return b.window.Add(b.limit.Interval)
+}
+
+// CompositeLimiter enforces several rate limit buckets at once.
+// A request proceeds only when its cost fits in every bucket; otherwise it
+// waits for the window of the fullest bucket to reset.
+//
+// Key features:
+//   - Configured from exchangeInfo rateLimits (REQUEST_WEIGHT, ORDERS, RAW_REQUESTS)
+//   - Reconciled with server usage headers (X-MBX-USED-WEIGHT-*, X-MBX-ORDER-COUNT-*)
+//   - Thread-safe for concurrent use
+type CompositeLimiter struct {
+	mu      sync.Mutex
+	buckets []*bucket
+}
+
+// NewCompositeLimiter creates a limiter with the given buckets.
+// An empty limits uses DefaultLimits.
+func NewCompositeLimiter(limits []Limit) *CompositeLimiter {
+	if len(limits) == 0 {
+		limits = DefaultLimits
+	}
+	cl := &CompositeLimiter{}
+	cl.Configure(limits)
+	return cl
+}
+
+// Configure replaces the buckets, e.g. after loading exchangeInfo.
+// Usage is carried over for buckets with the same type and interval.
+// Limits with a non-positive interval or limit are ignored.
+func (cl *CompositeLimiter) Configure(limits []Limit) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	buckets := make([]*bucket, 0, len(limits))
+	for _, limit := range limits {
+		if limit.Interval <= 0 || limit.Limit <= 0 {
+			continue
+		}
+		b := &bucket{limit: limit}
+		if old := cl.find(limit.Type, limit.Interval); old != nil {
+			b.used = old.used
+			b.window = old.window
+		}
+		buckets = append(buckets, b)
+	}
+	cl.buckets = buckets
+}
+
+// find returns the bucket for a type and interval, or nil.
+// Callers must hold cl.mu.
+func (cl *CompositeLimiter) find(limitType string, interval time.Duration) *bucket {
+	for _, b := range cl.buckets {
+		if b.limit.Type == limitType && b.limit.Interval == interval {
+			return b
+		}
+	}
+	return nil
+}
+
+// Wait blocks until cost fits in every bucket, then consumes it.
+// Returns ctx.Err() if the context is cancelled first, or an error if the
+// cost exceeds a bucket's limit and can never be satisfied.
+func (cl *CompositeLimiter) Wait(ctx context.Context, cost Cost) error {
+	for {
+		wait, err := cl.reserve(cost, time.Now())
+		if err != nil || wait == 0 {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Allow consumes cost if it fits in every bucket without waiting.
+func (cl *CompositeLimiter) Allow(cost Cost) bool {
+	wait, err := cl.reserve(cost, time.Now())
+	return err == nil && wait == 0
+}
+
+// reserve consumes cost if every bucket has room.
+// Otherwise nothing is consumed and the wait until the blocking bucket with
+// the latest reset is returned.
+func (cl *CompositeLimiter) reserve(cost Cost, now time.Time) (time.Duration, error) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	var wait time.Duration
+	for _, b := range cl.buckets {
+		n := cost.units(b.limit.Type)
+		if n <= 0 {
+			continue
+		}
+		if n > b.limit.Limit {
+			return 0, fmt.Errorf("ratelimit: cost %d exceeds %s limit of %d per %s", n, b.limit.Type, b.limit.Limit, b.limit.Interval)
+		}
+
+		b.roll(now)
+		if b.used+n > b.limit.Limit {
+			// At least 1ms so a window ending exactly now is rolled on retry
+			wait = max(wait, b.resetsAt().Sub(now), time.Millisecond)
+		}
+	}
+	if wait > 0 {
+		return wait, nil
+	}
+
+	for _, b := range cl.buckets {
+		b.used += cost.units(b.limit.Type)
+	}
+	return 0, nil
+}
+
+// Reconcile updates a bucket's usage from the server's count.
+// The server count is authoritative for requests it has seen, but excludes
+// requests still in flight, so the larger of the two counts is kept.
+// Unknown buckets are ignored.
+func (cl *CompositeLimiter) Reconcile(limitType string, interval time.Duration, used int) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	b := cl.find(limitType, interval)
+	if b == nil {
+		return
+	}
+	b.roll(time.Now())
+	if used > b.used {
+		b.used = used
+	}
+}
+
+// Used returns the usage of a bucket in its current window.
+// Returns 0 for unknown buckets.
+func (cl *CompositeLimiter) Used(limitType string, interval time.Duration) int {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	b := cl.find(limitType, interval)
+	if b == nil {
+		return 0
+	}
+	b.roll(time.Now())
+	return b.used
+}
+
+// Limits returns the configured buckets.
+func (cl *CompositeLimiter) Limits() []Limit {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	limits := make([]Limit, len(cl.buckets))
+	for i, b := range cl.buckets {
+		limits[i] = b.limit
+	}
+	return limits
+}
+
+// Stats returns the state of every bucket.
+func (cl *CompositeLimiter) Stats() []BucketStats {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	now := time.Now()
+	stats := make([]BucketStats, len(cl.buckets))
+	for i, b := range cl.buckets {
+		b.roll(now)
+		stats[i] = BucketStats{
+			Type:      b.limit.Type,
+			Interval:  b.limit.Interval,
+			Limit:     b.limit.Limit,
+			Used:      b.used,
+			Remaining: max(b.limit.Limit-b.used, 0),
+			ResetsAt:  b.resetsAt(),
+		}
+	}
+	return stats
+}
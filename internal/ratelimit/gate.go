@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// GateStatus describes the state of a Gate.
+type GateStatus struct {
+	Closed bool      `json:"closed"` // Traffic is held back
+	Banned bool      `json:"banned"` // Closed by an IP ban
+	Until  time.Time `json:"until"`  // When the gate reopens
+	Reason error     `json:"-"`      // Error that closed the gate
+}
+
+// Gate holds back all traffic to an exchange after an IP-level rate limit
+// response, so a single misbehaving caller cannot escalate a 429 into a ban.
+//
+// One gate is shared by the REST client and every WebSocket connection of a
+// driver. While closed by a rate limit (429 with Retry-After), requests block
+// until it reopens. While closed by an IP ban (418), requests are rejected
+// immediately with the ban error, since a ban can last for hours.
+//
+// Thread-safe for concurrent use.
+type Gate struct {
+	mu      sync.RWMutex
+	until   time.Time
+	reason  error
+	banned  bool
+	onClose func(reason error)
+}
+
+// NewGate creates an open gate.
+func NewGate() *Gate {
+	return &Gate{}
+}
+
+// OnClose sets the callback invoked each time the gate is closed or extended.
+func (g *Gate) OnClose(fn func(reason error)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onClose = fn
+}
+
+// Close holds traffic back until the given time.
+// A close that ends before the current one is ignored, so the longest backoff
+// wins. reason is returned to rejected callers; an *errors.IPBanError marks the
+// gate as banned.
+func (g *Gate) Close(until time.Time, reason error) {
+	g.mu.Lock()
+	if !until.After(g.until) || !until.After(time.Now()) {
+		g.mu.Unlock()
+		return
+	}
+	var banErr *errors.IPBanError
+	g.until = until
+	g.reason = reason
+	g.banned = errors.As(reason, &banErr)
+	onClose := g.onClose
+	g.mu.Unlock()
+
+	if onClose != nil {
+		onClose(reason)
+	}
+}
+
+// Status returns the current state of the gate.
+func (g *Gate) Status() GateStatus {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !time.Now().Before(g.until) {
+		return GateStatus{}
+	}
+	return GateStatus{
+		Closed: true,
+		Banned: g.banned,
+		Until:  g.until,
+		Reason: g.reason,
+	}
+}
+
+// Allow returns nil if the gate is open, or the error that closed it.
+func (g *Gate) Allow() error {
+	if status := g.Status(); status.Closed {
+		return status.Reason
+	}
+	return nil
+}
+
+// Enter admits a request, blocking while the gate is closed by a rate limit.
+// It returns the closing error without waiting during an IP ban, or when ctx
+// would expire before the gate reopens.
+func (g *Gate) Enter(ctx context.Context) error {
+	for {
+		status := g.Status()
+		if !status.Closed {
+			return nil
+		}
+		if status.Banned {
+			return status.Reason
+		}
+		if deadline, ok := ctx.Deadline(); ok && deadline.Before(status.Until) {
+			return status.Reason
+		}
+		if err := sleepUntil(ctx, status.Until); err != nil {
+			return err
+		}
+	}
+}
+
+// Wait blocks until the gate is open, including through an IP ban.
+// Intended for background reconnect loops.
+func (g *Gate) Wait(ctx context.Context) error {
+	for {
+		status := g.Status()
+		if !status.Closed {
+			return nil
+		}
+		if err := sleepUntil(ctx, status.Until); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepUntil blocks until t or until ctx is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -1,4 +1,4 @@
-// Package ratelimit provides weight-based rate limiting for exchange APIs.
+// Package ratelimit provides weight-based and multi-bucket rate limiting for exchange APIs.
 // This package is specifically designed for Binance's weight-based rate limiting system.
 package ratelimit
 
@@ -15,24 +15,46 @@ import (
 
 // ClockSync maintains synchronized time with exchange server.
 // Binance requires clock offset < 500ms for signed requests.
+//
+// Each sync takes a burst of server time samples and keeps those with the
+// lowest round-trip time, since queuing delays only ever add latency. Outliers
+// are rejected and the remaining offsets averaged, NTP-style, so a single slow
+// request does not shift the clock. Drift is estimated from the offset history
+// and applied between syncs.
 type ClockSync struct {
 	exchange string
 
 	// State
-	offset   atomic.Int64 // Server time - Local time (in milliseconds)
-	lastSync atomic.Int64 // Last sync timestamp (Unix milliseconds)
+	state atomic.Pointer[clockState] // nil until the first sync
 
 	// Configuration
 	maxOffset    time.Duration // Maximum allowed offset
 	syncInterval time.Duration // How often to sync
+	samples      int           // Samples per sync
+	bestSamples  int           // Lowest-RTT samples kept per sync
 	timeProvider TimeProvider  // Function to get server time
 
 	// Control
-	mutex   sync.Mutex
+	mutex   sync.Mutex // Guards timeProvider and history
+	syncMu  sync.Mutex // Serializes syncs
+	history []estimate // Recent filtered offsets, oldest first
 	stopCh  chan struct{}
 	running atomic.Bool
 }
 
+// clockState is the current offset estimate.
+type clockState struct {
+	offset time.Duration // Filtered offset at the time of the sync
+	drift  float64       // Offset change per second of local time
+	at     time.Time     // Local time of the sync
+	stats  ClockStats
+}
+
+// offsetAt returns the offset extrapolated to local time now.
+func (s *clockState) offsetAt(now time.Time) time.Duration {
+	return s.offset + time.Duration(s.drift*float64(now.Sub(s.at)))
+}
+
 // TimeProvider returns the server time in milliseconds.
 type TimeProvider func(ctx context.Context) (int64, error)
 
@@ -40,14 +62,38 @@ type TimeProvider func(ctx context.Context) (int64, error)
 type ClockConfig struct {
 	MaxOffset    time.Duration // Maximum allowed offset (default: 500ms)
 	SyncInterval time.Duration // Sync interval (default: 5m)
+	Samples      int           // Server time samples per sync (default: 8)
+	BestSamples  int           // Lowest-RTT samples used for the estimate (default: half of Samples)
 	TimeProvider TimeProvider  // Function to get server time
 }
 
+// ClockStats describes the quality of the clock estimate.
+type ClockStats struct {
+	Offset     time.Duration `json:"offset"`      // Filtered offset (server - local), including drift
+	DriftPPM   float64       `json:"drift_ppm"`   // Offset change in parts per million of elapsed time
+	RTT        time.Duration `json:"rtt"`         // Lowest round-trip time of the last sync
+	Jitter     time.Duration `json:"jitter"`      // Standard deviation of the accepted samples
+	ErrorBound time.Duration `json:"error_bound"` // Maximum error of Offset: RTT/2 + Jitter
+	Confidence float64       `json:"confidence"`  // 0 (unusable) to 1 (error bound negligible next to MaxOffset)
+	Samples    int           `json:"samples"`     // Samples taken in the last sync
+	Accepted   int           `json:"accepted"`    // Samples used for the estimate
+	LastSync   time.Time     `json:"last_sync"`   // Time of the last successful sync
+}
+
+const (
+	// maxHistory is the number of sync results kept for drift estimation.
+	maxHistory = 8
+
+	// syncTimeout bounds a whole burst of samples.
+	syncTimeout = 10 * time.Second
+)
+
 // DefaultClockConfig returns default clock configuration.
 func DefaultClockConfig() ClockConfig {
 	return ClockConfig{
 		MaxOffset:    500 * time.Millisecond,
 		SyncInterval: 5 * time.Minute,
+		Samples:      8,
 	}
 }
 
@@ -59,24 +105,36 @@ func NewClockSync(exchange string, cfg ClockConfig) *ClockSync {
 	if cfg.SyncInterval == 0 {
 		cfg.SyncInterval = DefaultClockConfig().SyncInterval
 	}
+	if cfg.Samples <= 0 {
+		cfg.Samples = DefaultClockConfig().Samples
+	}
+	if cfg.BestSamples <= 0 || cfg.BestSamples > cfg.Samples {
+		cfg.BestSamples = max(cfg.Samples/2, 1)
+	}
 
 	return &ClockSync{
 		exchange:     exchange,
 		maxOffset:    cfg.MaxOffset,
 		syncInterval: cfg.SyncInterval,
+		samples:      cfg.Samples,
+		bestSamples:  cfg.BestSamples,
 		timeProvider: cfg.TimeProvider,
 		stopCh:       make(chan struct{}),
 	}
 }
 
 // Start begins periodic clock synchronization.
+// An offset beyond MaxOffset is still applied and periodic sync still starts;
+// the ClockSyncError is returned so the caller can report it.
 func (cs *ClockSync) Start() error {
 	if cs.running.Swap(true) {
 		return nil // Already running
 	}
 
 	// Initial sync
-	if err := cs.Sync(); err != nil {
+	err := cs.Sync()
+	var clockErr *errors.ClockSyncError
+	if err != nil && !errors.As(err, &clockErr) {
 		cs.running.Store(false)
 		return err
 	}
@@ -89,7 +147,7 @@ func (cs *ClockSync) Start() error {
 		Dur("interval", cs.syncInterval).
 		Msg("clock sync started")
 
-	return nil
+	return err
 }
 
 // Stop stops the clock synchronizer.
@@ -102,47 +160,93 @@ func (cs *ClockSync) Stop() {
 	log.Info().Str("exchange", cs.exchange).Msg("clock sync stopped")
 }
 
-// Sync performs a single clock synchronization.
+// Sync performs a single clock synchronization from a burst of samples.
+// Failed samples are skipped; Sync fails only if every sample fails.
+// The estimate is applied even if it exceeds MaxOffset, in which case a
+// ClockSyncError is returned.
 func (cs *ClockSync) Sync() error {
-	if cs.timeProvider == nil {
+	cs.mutex.Lock()
+	provider := cs.timeProvider
+	cs.mutex.Unlock()
+	if provider == nil {
 		return errors.NewValidationError("timeProvider", nil, "must not be nil")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	cs.syncMu.Lock()
+	defer cs.syncMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
 	defer cancel()
 
-	// Measure round-trip time
-	localStart := time.Now().UnixMilli()
-	serverTime, err := cs.timeProvider(ctx)
-	if err != nil {
-		return errors.NewConnectionError(cs.exchange, "clock", "sync failed: "+err.Error(), true)
+	samples := make([]sample, 0, cs.samples)
+	var lastErr error
+	for range cs.samples {
+		// time.Now carries a monotonic reading, so RTT is immune to clock steps
+		sent := time.Now()
+		serverTime, err := provider(ctx)
+		received := time.Now()
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		samples = append(samples, newSample(sent, received, serverTime))
+	}
+	if len(samples) == 0 {
+		return errors.NewConnectionError(cs.exchange, "clock", "sync failed: "+lastErr.Error(), true)
 	}
-	localEnd := time.Now().UnixMilli()
-
-	// Calculate offset (accounting for network latency)
-	// Assume server time is at midpoint of round-trip
-	localMid := (localStart + localEnd) / 2
-	offset := serverTime - localMid
 
-	cs.offset.Store(offset)
-	cs.lastSync.Store(time.Now().UnixMilli())
+	result := filterSamples(samples, cs.bestSamples)
+	now := time.Now()
+	drift := cs.recordEstimate(estimate{at: now, offset: result.offset})
+
+	errorBound := result.rtt/2 + result.jitter
+	confidence := float64(result.accepted) / float64(result.kept)
+	confidence *= 1 - min(float64(errorBound)/float64(cs.maxOffset), 1)
+
+	cs.state.Store(&clockState{
+		offset: result.offset,
+		drift:  drift,
+		at:     now,
+		stats: ClockStats{
+			Offset:     result.offset,
+			DriftPPM:   drift * 1e6,
+			RTT:        result.rtt,
+			Jitter:     result.jitter,
+			ErrorBound: errorBound,
+			Confidence: confidence,
+			Samples:    len(samples),
+			Accepted:   result.accepted,
+			LastSync:   now,
+		},
+	})
 
 	log.Debug().
 		Str("exchange", cs.exchange).
-		Int64("offset_ms", offset).
+		Dur("offset", result.offset).
+		Dur("rtt", result.rtt).
+		Dur("jitter", result.jitter).
+		Int("accepted", result.accepted).
+		Int("samples", len(samples)).
 		Msg("clock synchronized")
 
 	// Check if offset is acceptable
-	if abs(offset) > cs.maxOffset.Milliseconds() {
-		return errors.NewClockSyncError(
-			cs.exchange,
-			time.UnixMilli(localMid),
-			time.UnixMilli(serverTime),
-			time.Duration(abs(offset))*time.Millisecond,
-		)
-	}
+	return cs.ValidateOffset()
+}
 
-	return nil
+// recordEstimate appends a sync result to the history and returns the
+// drift estimated from it.
+func (cs *ClockSync) recordEstimate(e estimate) float64 {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.history = append(cs.history, e)
+	if len(cs.history) > maxHistory {
+		cs.history = cs.history[len(cs.history)-maxHistory:]
+	}
+	return estimateDrift(cs.history)
 }
 
 // syncLoop runs periodic synchronization.
@@ -164,42 +268,66 @@ func (cs *ClockSync) syncLoop() {
 
 // Now returns the current synchronized time.
 func (cs *ClockSync) Now() time.Time {
-	offset := cs.offset.Load()
-	return time.UnixMilli(time.Now().UnixMilli() + offset)
+	now := time.Now()
+	return now.Add(cs.offsetAt(now))
 }
 
 // UnixMilli returns the current synchronized time in milliseconds.
 func (cs *ClockSync) UnixMilli() int64 {
-	offset := cs.offset.Load()
-	return time.Now().UnixMilli() + offset
+	return cs.Now().UnixMilli()
 }
 
-// Offset returns the current clock offset.
+// Offset returns the current clock offset, including drift since the last sync.
 func (cs *ClockSync) Offset() time.Duration {
-	return time.Duration(cs.offset.Load()) * time.Millisecond
+	return cs.offsetAt(time.Now())
+}
+
+// offsetAt returns the offset at local time now, or 0 before the first sync.
+func (cs *ClockSync) offsetAt(now time.Time) time.Duration {
+	state := cs.state.Load()
+	if state == nil {
+		return 0
+	}
+	return state.offsetAt(now)
+}
+
+// Stats returns the quality of the current estimate.
+// Returns zero stats before the first sync.
+func (cs *ClockSync) Stats() ClockStats {
+	state := cs.state.Load()
+	if state == nil {
+		return ClockStats{}
+	}
+	stats := state.stats
+	stats.Offset = state.offsetAt(time.Now())
+	return stats
 }
 
 // IsSynchronized returns true if clock has been synchronized.
 func (cs *ClockSync) IsSynchronized() bool {
-	return cs.lastSync.Load() > 0
+	return cs.state.Load() != nil
 }
 
 // LastSync returns the time of the last synchronization.
 func (cs *ClockSync) LastSync() time.Time {
-	return time.UnixMilli(cs.lastSync.Load())
+	state := cs.state.Load()
+	if state == nil {
+		return time.Time{}
+	}
+	return state.at
 }
 
-// ValidateOffset checks if the current offset is within acceptable range.
+// ValidateOffset checks if the filtered offset is within acceptable range.
 func (cs *ClockSync) ValidateOffset() error {
-	offset := abs(cs.offset.Load())
-	maxMs := cs.maxOffset.Milliseconds()
+	now := time.Now()
+	offset := cs.offsetAt(now)
 
-	if offset > maxMs {
+	if absDuration(offset) > cs.maxOffset {
 		return errors.NewClockSyncError(
 			cs.exchange,
-			time.Now(),
-			cs.Now(),
-			time.Duration(offset)*time.Millisecond,
+			now,
+			now.Add(offset),
+			absDuration(offset),
 		)
 	}
 
@@ -212,11 +340,3 @@ func (cs *ClockSync) SetTimeProvider(provider TimeProvider) {
 	defer cs.mutex.Unlock()
 	cs.timeProvider = provider
 }
-
-// abs returns the absolute value of an int64.
-func abs(n int64) int64 {
-	if n < 0 {
-		return -n
-	}
-	return n
-}
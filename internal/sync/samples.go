@@ -0,0 +1,168 @@
+package sync
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// sample is a single server time measurement.
+type sample struct {
+	offset time.Duration // Server time - local time at the midpoint of the round trip
+	rtt    time.Duration // Round-trip time
+}
+
+// newSample computes the offset of one round trip, NTP-style.
+// The server stamps its reply somewhere within the round trip; assuming a
+// symmetric path, the error of the midpoint estimate is at most rtt/2.
+// serverMs is truncated to the millisecond, so half a millisecond is added to
+// center it.
+func newSample(sent, received time.Time, serverMs int64) sample {
+	rtt := received.Sub(sent)
+	mid := sent.Add(rtt / 2)
+	server := time.UnixMilli(serverMs).Add(time.Millisecond / 2)
+	return sample{offset: server.Sub(mid), rtt: rtt}
+}
+
+// filterResult is the offset estimated from a burst of samples.
+type filterResult struct {
+	offset   time.Duration // Estimated offset
+	rtt      time.Duration // Lowest round-trip time
+	jitter   time.Duration // Standard deviation of accepted offsets
+	kept     int           // Samples kept after RTT filtering
+	accepted int           // Kept samples that were not outliers
+}
+
+// minOutlierThreshold keeps a millisecond of spread from being rejected,
+// since server timestamps only have millisecond resolution.
+const minOutlierThreshold = time.Millisecond
+
+// filterSamples estimates the offset from a burst of samples.
+//
+// Samples with the lowest round-trip time are the least affected by queuing
+// delays, so only the best samples are kept. Of those, offsets further than
+// three median absolute deviations from the median are rejected as outliers,
+// and the rest are averaged weighted by the inverse of their round-trip time.
+func filterSamples(samples []sample, best int) filterResult {
+	sorted := slices.Clone(samples)
+	slices.SortFunc(sorted, func(a, b sample) int {
+		return cmp.Compare(a.rtt, b.rtt)
+	})
+	if best > 0 && len(sorted) > best {
+		sorted = sorted[:best]
+	}
+
+	offsets := make([]time.Duration, len(sorted))
+	for i, s := range sorted {
+		offsets[i] = s.offset
+	}
+	median := medianDuration(offsets)
+
+	deviations := make([]time.Duration, len(sorted))
+	for i, s := range sorted {
+		deviations[i] = absDuration(s.offset - median)
+	}
+	threshold := max(3*medianDuration(deviations), minOutlierThreshold)
+
+	var weighted, weights float64
+	accepted := make([]time.Duration, 0, len(sorted))
+	for _, s := range sorted {
+		if absDuration(s.offset-median) > threshold {
+			continue
+		}
+		w := 1 / float64(max(s.rtt, time.Microsecond))
+		weighted += w * float64(s.offset)
+		weights += w
+		accepted = append(accepted, s.offset)
+	}
+
+	return filterResult{
+		offset:   time.Duration(weighted / weights),
+		rtt:      sorted[0].rtt,
+		jitter:   stddevDuration(accepted),
+		kept:     len(sorted),
+		accepted: len(accepted),
+	}
+}
+
+// estimate is a point in the offset history, used to estimate drift.
+type estimate struct {
+	at     time.Time     // Local time of the estimate
+	offset time.Duration // Filtered offset
+}
+
+const (
+	// minDriftSpan is the shortest history over which drift is estimated;
+	// over shorter spans measurement noise dominates the slope.
+	minDriftSpan = time.Minute
+
+	// maxDrift bounds the drift estimate. Quartz clocks drift well under
+	// 100ppm, so anything larger is noise or a clock step.
+	maxDrift = 500e-6
+)
+
+// estimateDrift returns the rate at which the offset changes (seconds per
+// second of local time), from a least-squares fit over the history.
+// Returns 0 with fewer than three points or less than minDriftSpan of history.
+func estimateDrift(history []estimate) float64 {
+	if len(history) < 3 || history[len(history)-1].at.Sub(history[0].at) < minDriftSpan {
+		return 0
+	}
+
+	origin := history[0].at
+	var sumX, sumY, sumXX, sumXY float64
+	for _, e := range history {
+		x := e.at.Sub(origin).Seconds()
+		y := e.offset.Seconds()
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+	n := float64(len(history))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	drift := (n*sumXY - sumX*sumY) / denominator
+	return math.Max(-maxDrift, math.Min(drift, maxDrift))
+}
+
+// medianDuration returns the median of values. values must not be empty.
+func medianDuration(values []time.Duration) time.Duration {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// stddevDuration returns the population standard deviation of values.
+func stddevDuration(values []time.Duration) time.Duration {
+	if len(values) < 2 {
+		return 0
+	}
+	var mean float64
+	for _, v := range values {
+		mean += float64(v)
+	}
+	mean /= float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		d := float64(v) - mean
+		variance += d * d
+	}
+	return time.Duration(math.Sqrt(variance / float64(len(values))))
+}
+
+// absDuration returns the absolute value of a duration.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
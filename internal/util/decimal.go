@@ -0,0 +1,21 @@
+// Package util provides small helpers shared by the drivers and the connector.
+package util
+
+import "github.com/lilwiggy/ex-act/pkg/domain"
+
+// OrZero returns d, or zero if d is nil.
+func OrZero(d domain.Decimal) domain.Decimal {
+	if d == nil {
+		return domain.Zero()
+	}
+	return d
+}
+
+// ParseOrZero parses a decimal string, returning zero if empty or invalid.
+func ParseOrZero(s string) domain.Decimal {
+	d, err := domain.NewDecimal(s)
+	if err != nil {
+		return domain.Zero()
+	}
+	return OrZero(d)
+}
@@ -0,0 +1,158 @@
+package connector
+
+import (
+	stdsync "sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+)
+
+// BalanceBook is an in-memory view of account balances.
+// It is seeded from a REST snapshot and kept current from the user data stream.
+// All methods are safe for concurrent use.
+//
+// IMPORTANT: Updates are ordered by timestamp. A snapshot that is older than
+// a stream update already applied does not overwrite it.
+type BalanceBook struct {
+	mu       stdsync.RWMutex
+	balances map[string]domain.Balance
+	synced   time.Time
+}
+
+// NewBalanceBook creates an empty balance book.
+func NewBalanceBook() *BalanceBook {
+	return &BalanceBook{
+		balances: make(map[string]domain.Balance),
+	}
+}
+
+// Get returns the balance for an asset.
+func (b *BalanceBook) Get(asset string) (domain.Balance, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	balance, ok := b.balances[asset]
+	if !ok {
+		return domain.Balance{}, false
+	}
+	return copyBalance(balance), true
+}
+
+// Free returns the free balance for an asset, or zero if unknown.
+func (b *BalanceBook) Free(asset string) domain.Decimal {
+	balance, ok := b.Get(asset)
+	if !ok {
+		return domain.Zero()
+	}
+	return balance.Free
+}
+
+// Locked returns the locked balance for an asset, or zero if unknown.
+func (b *BalanceBook) Locked(asset string) domain.Decimal {
+	balance, ok := b.Get(asset)
+	if !ok {
+		return domain.Zero()
+	}
+	return balance.Locked
+}
+
+// All returns a copy of every known balance.
+func (b *BalanceBook) All() []domain.Balance {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	balances := make([]domain.Balance, 0, len(b.balances))
+	for _, balance := range b.balances {
+		balances = append(balances, copyBalance(balance))
+	}
+	return balances
+}
+
+// LastSync returns the time of the last snapshot, or zero if never seeded.
+func (b *BalanceBook) LastSync() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// reset replaces the book with a snapshot.
+// Entries updated by the stream after the snapshot was taken are kept.
+func (b *BalanceBook) reset(snapshot []domain.Balance) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	balances := make(map[string]domain.Balance, len(snapshot))
+	var taken time.Time
+	for _, balance := range snapshot {
+		balances[balance.Asset] = copyBalance(balance)
+		if balance.Timestamp.After(taken) {
+			taken = balance.Timestamp
+		}
+	}
+
+	for asset, current := range b.balances {
+		if fresh, ok := balances[asset]; ok && !current.Timestamp.After(fresh.Timestamp) {
+			continue
+		}
+		if current.Timestamp.After(taken) {
+			balances[asset] = current
+		}
+	}
+
+	b.balances = balances
+	b.synced = time.Now()
+}
+
+// set applies a full balance update.
+// Returns false if the update is older than the stored balance.
+func (b *BalanceBook) set(balance *domain.Balance) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if current, ok := b.balances[balance.Asset]; ok && balance.Timestamp.Before(current.Timestamp) {
+		return false
+	}
+	b.balances[balance.Asset] = copyBalance(*balance)
+	return true
+}
+
+// applyDelta adds delta to an asset's free balance and returns the result.
+// Returns false if the delta is not newer than the stored balance, since the
+// snapshot already includes it.
+func (b *BalanceBook) applyDelta(exchange, asset string, delta domain.Decimal, at time.Time) (domain.Balance, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current, ok := b.balances[asset]
+	if ok && !at.After(current.Timestamp) {
+		return domain.Balance{}, false
+	}
+	if !ok {
+		current = domain.Balance{
+			Exchange: exchange,
+			Asset:    asset,
+			Free:     domain.Zero(),
+			Locked:   domain.Zero(),
+		}
+	}
+
+	current.Free = domain.Add(current.Free, delta)
+	current.Timestamp = at
+	b.balances[asset] = current
+	return copyBalance(current), true
+}
+
+// copyBalance returns a balance with its own decimal values.
+func copyBalance(balance domain.Balance) domain.Balance {
+	balance.Free = copyDecimal(balance.Free)
+	balance.Locked = copyDecimal(balance.Locked)
+	return balance
+}
+
+// copyDecimal returns a copy of d, or zero if d is nil.
+func copyDecimal(d domain.Decimal) domain.Decimal {
+	if d == nil {
+		return domain.Zero()
+	}
+	return domain.Add(d, domain.Zero())
+}
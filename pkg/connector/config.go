@@ -3,8 +3,10 @@ package connector
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/lilwiggy/ex-act/pkg/driver"
 	"github.com/lilwiggy/ex-act/pkg/errors"
 )
 
@@ -22,25 +24,68 @@ type Config struct {
 
 	// Connection
 	Connection ConnectionConfig
+
+	// Safety
+	DeadMan DeadManConfig
+
+	// Order state
+	Reconcile ReconcileConfig
+
+	// Symbol metadata
+	Symbols SymbolsConfig
 }
 
 // ExchangeConfig contains exchange-specific settings.
 type ExchangeConfig struct {
-	Name      string // Exchange name: "binance" or "bybit"
+	Name      string // Exchange name, must match a registered driver (e.g., "binance", "bybit")
 	APIKey    string // API key for authentication
-	APISecret string // API secret for signing
+	APISecret string // API secret for signing, or PKCS#8 PEM private key for RSA and Ed25519 keys
 	Testnet   bool   // Use testnet endpoints
+
+	// KeyType is the API key type: driver.KeyTypeHMAC (default),
+	// driver.KeyTypeRSA or driver.KeyTypeEd25519. The key is validated when
+	// the connector is created.
+	KeyType string
+
+	// RecvWindow is how long a signed request stays valid after its timestamp
+	// (0 = exchange default of 5s, max 60s)
+	RecvWindow time.Duration
+
+	// OrderTransport selects how orders are placed, cancelled and queried:
+	// driver.OrderTransportREST (default) or driver.OrderTransportWebSocket.
+	// The WebSocket transport requires credentials and a driver that supports it.
+	OrderTransport string
 }
 
+// MaxRecvWindow is the largest recvWindow accepted by the exchanges.
+const MaxRecvWindow = 60 * time.Second
+
 // Validate validates exchange configuration.
 func (c *ExchangeConfig) Validate() error {
 	if c.Name == "" {
 		return errors.NewValidationError("name", "", "must not be empty")
 	}
-	if c.Name != "binance" && c.Name != "bybit" {
-		return errors.NewValidationError("name", c.Name, "must be 'binance' or 'bybit'")
+	if !driver.IsRegistered(c.Name) {
+		return errors.NewValidationError("name", c.Name, fmt.Sprintf("no driver registered (available: %s)", strings.Join(driver.Registered(), ", ")))
 	}
 	// APIKey and APISecret can be empty for public-only access
+	switch c.KeyType {
+	case "", driver.KeyTypeHMAC, driver.KeyTypeRSA, driver.KeyTypeEd25519:
+	default:
+		return errors.NewValidationError("key_type", c.KeyType, "must be HMAC, RSA or ED25519")
+	}
+	if c.RecvWindow < 0 || c.RecvWindow > MaxRecvWindow {
+		return errors.NewValidationError("recv_window", c.RecvWindow, fmt.Sprintf("must be between 0 and %s", MaxRecvWindow))
+	}
+	switch c.OrderTransport {
+	case "", driver.OrderTransportREST:
+	case driver.OrderTransportWebSocket:
+		if c.APIKey == "" || c.APISecret == "" {
+			return errors.NewValidationError("order_transport", c.OrderTransport, "requires API credentials")
+		}
+	default:
+		return errors.NewValidationError("order_transport", c.OrderTransport, "must be rest or websocket")
+	}
 	return nil
 }
 
@@ -94,12 +139,124 @@ func DefaultClockSyncConfig() ClockSyncConfig {
 	}
 }
 
+// DeadManConfig contains dead-man's switch settings.
+// When enabled, all open orders are cancelled on every tracked symbol if the
+// user data stream stays disconnected, or the application stops calling
+// Connector.Heartbeat, for longer than the configured timeout.
+type DeadManConfig struct {
+	DisconnectTimeout time.Duration // User data stream downtime before cancelling (0 = disabled)
+	HeartbeatTimeout  time.Duration // Time without Heartbeat before cancelling (0 = disabled)
+	CheckInterval     time.Duration // How often the timeouts are checked (default: 1s)
+
+	// NativeWindow is the exchange-side countdown armed where the venue
+	// supports one (e.g., Bybit DCP), which also fires if this process is
+	// frozen (default: DisconnectTimeout; 0 with DisconnectTimeout 0 = not armed)
+	NativeWindow time.Duration
+
+	Enabled bool // Enable the dead-man's switch (default: false)
+}
+
+// DefaultDeadManConfig returns default dead-man's switch configuration.
+func DefaultDeadManConfig() DeadManConfig {
+	return DeadManConfig{
+		DisconnectTimeout: 10 * time.Second,
+		CheckInterval:     time.Second,
+		Enabled:           false,
+	}
+}
+
+// Validate validates dead-man's switch configuration.
+func (c *DeadManConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.DisconnectTimeout < 0 {
+		return errors.NewValidationError("disconnect_timeout", c.DisconnectTimeout, "must not be negative")
+	}
+	if c.HeartbeatTimeout < 0 {
+		return errors.NewValidationError("heartbeat_timeout", c.HeartbeatTimeout, "must not be negative")
+	}
+	if c.NativeWindow < 0 {
+		return errors.NewValidationError("native_window", c.NativeWindow, "must not be negative")
+	}
+	if c.DisconnectTimeout == 0 && c.HeartbeatTimeout == 0 {
+		return errors.NewValidationError("dead_man", nil, "at least one of disconnect_timeout and heartbeat_timeout is required")
+	}
+	return nil
+}
+
+// ReconcileConfig contains order reconciliation settings.
+// The reconciler compares the local order store with the exchange's open
+// orders and recent fills, after every connect and every Interval, and
+// applies the updates the user data stream missed.
+// It only runs with API credentials.
+type ReconcileConfig struct {
+	Interval time.Duration // Time between scheduled runs (0 = only after connects)
+	Lookback time.Duration // How far back the first run fetches fills (default: 5m)
+	Enabled  bool          // Enable reconciliation (default: true)
+}
+
+// DefaultReconcileConfig returns default reconciliation configuration.
+func DefaultReconcileConfig() ReconcileConfig {
+	return ReconcileConfig{
+		Interval: time.Minute,
+		Lookback: 5 * time.Minute,
+		Enabled:  true,
+	}
+}
+
+// Validate validates reconciliation configuration.
+func (c *ReconcileConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Interval < 0 {
+		return errors.NewValidationError("reconcile_interval", c.Interval, "must not be negative")
+	}
+	if c.Lookback < 0 {
+		return errors.NewValidationError("reconcile_lookback", c.Lookback, "must not be negative")
+	}
+	return nil
+}
+
+// SymbolsConfig contains symbol metadata settings.
+// Symbols and their trading rules are loaded from exchange info on first use,
+// refreshed every RefreshInterval, and reloaded on lookup once older than
+// TTL. Status changes are reported to OnSymbolStatus.
+type SymbolsConfig struct {
+	RefreshInterval time.Duration // Time between scheduled refreshes (0 = none)
+	TTL             time.Duration // Age after which lookups reload (0 = never expires)
+}
+
+// DefaultSymbolsConfig returns default symbol metadata configuration.
+func DefaultSymbolsConfig() SymbolsConfig {
+	return SymbolsConfig{
+		RefreshInterval: 30 * time.Minute,
+		TTL:             time.Hour,
+	}
+}
+
+// Validate validates symbol metadata configuration.
+func (c *SymbolsConfig) Validate() error {
+	if c.RefreshInterval < 0 {
+		return errors.NewValidationError("symbols_refresh_interval", c.RefreshInterval, "must not be negative")
+	}
+	if c.TTL < 0 {
+		return errors.NewValidationError("symbols_ttl", c.TTL, "must not be negative")
+	}
+	return nil
+}
+
 // ConnectionConfig contains connection settings.
 type ConnectionConfig struct {
 	Timeout          time.Duration // REST request timeout
 	PingInterval     time.Duration // WebSocket ping interval
 	ReconnectDelay   time.Duration // Initial reconnect delay
 	MaxReconnectWait time.Duration // Maximum reconnect wait
+
+	// MaxStreamsPerConn caps streams per WebSocket connection; larger
+	// subscription sets are sharded across connections (0 = exchange limit)
+	MaxStreamsPerConn int
 }
 
 // DefaultConnectionConfig returns default connection configuration.
@@ -126,18 +283,39 @@ func NewConfigBuilder() *Builder {
 			CircuitBreaker: DefaultCircuitBreakerConfig(),
 			ClockSync:      DefaultClockSyncConfig(),
 			Connection:     DefaultConnectionConfig(),
+			DeadMan:        DefaultDeadManConfig(),
+			Reconcile:      DefaultReconcileConfig(),
+			Symbols:        DefaultSymbolsConfig(),
 		},
 	}
 }
 
 // Exchange sets exchange configuration.
 func (b *Builder) Exchange(name, apiKey, apiSecret string, testnet bool) *Builder {
-	b.config.Exchange = ExchangeConfig{
-		Name:      name,
-		APIKey:    apiKey,
-		APISecret: apiSecret,
-		Testnet:   testnet,
-	}
+	b.config.Exchange.Name = name
+	b.config.Exchange.APIKey = apiKey
+	b.config.Exchange.APISecret = apiSecret
+	b.config.Exchange.Testnet = testnet
+	return b
+}
+
+// KeyType sets the API key type (driver.KeyTypeHMAC, KeyTypeRSA or KeyTypeEd25519).
+// For RSA and Ed25519, the API secret passed to Exchange is the PEM private key.
+func (b *Builder) KeyType(keyType string) *Builder {
+	b.config.Exchange.KeyType = keyType
+	return b
+}
+
+// RecvWindow sets how long signed requests stay valid after their timestamp.
+func (b *Builder) RecvWindow(window time.Duration) *Builder {
+	b.config.Exchange.RecvWindow = window
+	return b
+}
+
+// OrderTransport selects the order entry transport
+// (driver.OrderTransportREST or driver.OrderTransportWebSocket).
+func (b *Builder) OrderTransport(transport string) *Builder {
+	b.config.Exchange.OrderTransport = transport
 	return b
 }
 
@@ -171,17 +349,69 @@ func (b *Builder) ClockSync(maxOffset, interval time.Duration) *Builder {
 	return b
 }
 
+// DeadMan enables the dead-man's switch.
+// A zero timeout disables that trigger; at least one must be set.
+func (b *Builder) DeadMan(disconnectTimeout, heartbeatTimeout time.Duration) *Builder {
+	b.config.DeadMan = DeadManConfig{
+		DisconnectTimeout: disconnectTimeout,
+		HeartbeatTimeout:  heartbeatTimeout,
+		CheckInterval:     DefaultDeadManConfig().CheckInterval,
+		Enabled:           true,
+	}
+	return b
+}
+
+// Reconcile sets order reconciliation configuration.
+// An interval of zero reconciles only after connects.
+func (b *Builder) Reconcile(interval, lookback time.Duration) *Builder {
+	b.config.Reconcile = ReconcileConfig{
+		Interval: interval,
+		Lookback: lookback,
+		Enabled:  true,
+	}
+	return b
+}
+
+// SymbolRefresh sets how often symbols and their trading rules are reloaded.
+// An interval of zero disables scheduled refreshes.
+func (b *Builder) SymbolRefresh(interval time.Duration) *Builder {
+	b.config.Symbols.RefreshInterval = interval
+	return b
+}
+
+// SymbolTTL sets the age after which symbol lookups reload the listing.
+// A TTL of zero never expires it.
+func (b *Builder) SymbolTTL(ttl time.Duration) *Builder {
+	b.config.Symbols.TTL = ttl
+	return b
+}
+
 // Timeout sets connection timeout.
 func (b *Builder) Timeout(timeout time.Duration) *Builder {
 	b.config.Connection.Timeout = timeout
 	return b
 }
 
+// MaxStreamsPerConn sets the per-connection stream cap used for sharding.
+func (b *Builder) MaxStreamsPerConn(n int) *Builder {
+	b.config.Connection.MaxStreamsPerConn = n
+	return b
+}
+
 // Build validates and returns the configuration.
 func (b *Builder) Build() (Config, error) {
 	if err := b.config.Exchange.Validate(); err != nil {
 		b.errs = append(b.errs, err)
 	}
+	if err := b.config.DeadMan.Validate(); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	if err := b.config.Reconcile.Validate(); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	if err := b.config.Symbols.Validate(); err != nil {
+		b.errs = append(b.errs, err)
+	}
 
 	if len(b.errs) > 0 {
 		return Config{}, fmt.Errorf("configuration errors: %v", b.errs)
@@ -1,8 +1,10 @@
 package connector
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	stdsync "sync"
 	"sync/atomic"
 	"time"
@@ -10,24 +12,49 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/lilwiggy/ex-act/internal/circuit"
-	"github.com/lilwiggy/ex-act/internal/driver/binance"
 	internalSync "github.com/lilwiggy/ex-act/internal/sync"
 	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+
+	// Register built-in exchange drivers
+	_ "github.com/lilwiggy/ex-act/internal/driver/binance"
+	_ "github.com/lilwiggy/ex-act/internal/driver/bybit"
 )
 
 // Connector provides exchange connectivity with fault tolerance.
 // One Connector instance connects to one exchange.
+// The exchange implementation is selected from the driver registry by
+// ExchangeConfig.Name.
 type Connector struct {
 	config   Config
 	exchange string
 
 	// Components
-	restClient     *binance.RESTClient
-	wsClient       *binance.WSClient
+	driver         driver.Driver
 	circuitBreaker *circuit.Breaker
 	clockSync      *internalSync.ClockSync
 	nonceGen       *internalSync.NonceGenerator
 
+	// Market and account state
+	orderBooks *OrderBookStore
+	balances   *BalanceBook
+	orders     *OrderStore
+	symbols    *domain.SymbolRegistry
+	symbolInfo *symbolCache
+
+	// Streams
+	streams       map[driver.Subscription]int // Subscribers per driver stream
+	streamsMu     stdsync.Mutex
+	tickerFeed    feed[*domain.Ticker]
+	orderBookFeed feed[*domain.OrderBook]
+	tradeFeed     feed[*domain.Trade]
+	klineFeed     feed[*domain.Kline]
+
+	// Safety
+	deadMan    *DeadManSwitch // nil unless DeadMan.Enabled
+	reconciler *Reconciler    // nil unless Reconcile.Enabled with credentials
+
 	// State
 	running   atomic.Bool
 	ready     chan struct{}
@@ -47,16 +74,34 @@ func New(cfg Config) (*Connector, error) {
 	if err := cfg.Exchange.Validate(); err != nil {
 		return nil, err
 	}
+	if err := cfg.DeadMan.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Reconcile.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Symbols.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.DeadMan.Enabled && (cfg.Exchange.APIKey == "" || cfg.Exchange.APISecret == "") {
+		return nil, errors.NewValidationError("dead_man", nil, "requires API credentials")
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
+	symbols := domain.Symbols(cfg.Exchange.Name)
 
 	c := &Connector{
-		config:   cfg,
-		exchange: cfg.Exchange.Name,
-		ready:    make(chan struct{}),
-		nonceGen: internalSync.NewNonceGenerator(),
-		ctx:      ctx,
-		cancel:   cancel,
+		config:     cfg,
+		exchange:   cfg.Exchange.Name,
+		ready:      make(chan struct{}),
+		nonceGen:   internalSync.NewNonceGenerator(),
+		orderBooks: NewOrderBookStore(symbols),
+		balances:   NewBalanceBook(),
+		orders:     NewOrderStore(symbols),
+		symbols:    symbols,
+		streams:    make(map[driver.Subscription]int),
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 
 	// Initialize components
@@ -64,27 +109,47 @@ func New(cfg Config) (*Connector, error) {
 		cancel()
 		return nil, err
 	}
+	c.symbolInfo = newSymbolCache(c.exchangeInfo, c.symbols, cfg.Symbols.TTL, c.reportSymbolStatus, c.reportSymbolsError)
 
 	return c, nil
 }
 
 // initComponents initializes all components.
 func (c *Connector) initComponents() error {
-	var err error
+	driverCfg := driver.Config{
+		Name:              c.config.Exchange.Name,
+		APIKey:            c.config.Exchange.APIKey,
+		APISecret:         c.config.Exchange.APISecret,
+		KeyType:           c.config.Exchange.KeyType,
+		Testnet:           c.config.Exchange.Testnet,
+		Timeout:           c.config.Connection.Timeout,
+		MaxWeight:         c.config.RateLimit.MaxWeight,
+		RecvWindow:        c.config.Exchange.RecvWindow.Milliseconds(),
+		OrderTransport:    c.config.Exchange.OrderTransport,
+		PingInterval:      c.config.Connection.PingInterval,
+		ReconnectDelay:    c.config.Connection.ReconnectDelay,
+		MaxReconnectWait:  c.config.Connection.MaxReconnectWait,
+		MaxStreamsPerConn: c.config.Connection.MaxStreamsPerConn,
+	}
 
-	// Create REST client
-	restCfg := binance.Config{
-		BaseURL:   "", // Use default or testnet based on config
-		APIKey:    c.config.Exchange.APIKey,
-		APISecret: c.config.Exchange.APISecret,
-		Timeout:   c.config.Connection.Timeout,
-		MaxWeight: c.config.RateLimit.MaxWeight,
-		Testnet:   c.config.Exchange.Testnet,
+	// Create clock sync; the driver signs requests with it and resyncs it on
+	// timestamp rejections. The time provider is set once the driver exists.
+	if c.config.ClockSync.Enabled {
+		c.clockSync = internalSync.NewClockSync(c.exchange, internalSync.ClockConfig{
+			MaxOffset:    c.config.ClockSync.MaxOffset,
+			SyncInterval: c.config.ClockSync.SyncInterval,
+		})
+		driverCfg.Clock = c.clockSync
 	}
 
-	c.restClient, err = binance.NewRESTClient(restCfg)
+	// Create exchange driver
+	d, err := driver.New(driverCfg)
 	if err != nil {
-		return fmt.Errorf("failed to create REST client: %w", err)
+		return fmt.Errorf("failed to create driver: %w", err)
+	}
+	c.driver = d
+	if c.clockSync != nil {
+		c.clockSync.SetTimeProvider(d.GetServerTime)
 	}
 
 	// Create circuit breaker
@@ -96,74 +161,142 @@ func (c *Connector) initComponents() error {
 		})
 	}
 
-	// Create clock sync
-	if c.config.ClockSync.Enabled {
-		c.clockSync = internalSync.NewClockSync(c.exchange, internalSync.ClockConfig{
-			MaxOffset:    c.config.ClockSync.MaxOffset,
-			SyncInterval: c.config.ClockSync.SyncInterval,
-			TimeProvider: c.restClient.GetServerTime,
-		})
+	// Create dead-man's switch; cancel-all bypasses the circuit breaker since
+	// it is needed most when the exchange is failing, but its results are
+	// tracked like other REST responses
+	if c.config.DeadMan.Enabled {
+		c.deadMan = newDeadManSwitch(c.config.DeadMan, c.symbols, c.cancelAllTracked, c.reportDeadMan)
 	}
 
-	// Create WebSocket client
-	wsCfg := binance.WSConfig{
-		Testnet:      c.config.Exchange.Testnet,
-		PingInterval: c.config.Connection.PingInterval,
-		Reconnect: binance.ReconnectConfig{
-			InitialDelay: c.config.Connection.ReconnectDelay,
-			MaxDelay:     c.config.Connection.MaxReconnectWait,
-			MaxAttempts:  0, // Infinite
-			Jitter:       0.1,
-		},
+	// Create order reconciler; its queries bypass the circuit breaker and the
+	// REST response tracking so fills are applied together with their order
+	if c.config.Reconcile.Enabled && c.hasCredentials() {
+		c.reconciler = newReconciler(c.config.Reconcile, c.driver, c.orders, c.emitOrder, c.reportDiscrepancy, c.reportReconcileError)
 	}
 
-	c.wsClient = binance.NewWSClient(wsCfg)
-
-	// Set up WebSocket handlers
-	c.setupWSHandlers()
+	// Set up stream handlers
+	c.setupStreamHandlers()
 
 	return nil
 }
 
-// setupWSHandlers sets up WebSocket event handlers.
-func (c *Connector) setupWSHandlers() {
-	c.wsClient.OnTicker(func(ticker *domain.Ticker) {
-		if c.handlers.OnTicker != nil {
-			c.safeHandler(func() {
-				c.handlers.OnTicker(c.exchange, ticker)
-			})
-		}
-	})
-
-	c.wsClient.OnOrderBook(func(ob *domain.OrderBook) {
-		if c.handlers.OnOrderBook != nil {
-			c.safeHandler(func() {
-				c.handlers.OnOrderBook(c.exchange, ob)
-			})
-		}
-	})
-
-	c.wsClient.OnTrade(func(trade *domain.Trade) {
-		if c.handlers.OnTrade != nil {
-			c.safeHandler(func() {
-				c.handlers.OnTrade(c.exchange, trade)
-			})
-		}
-	})
-
-	c.wsClient.OnConnect(func() {
-		log.Info().Str("exchange", c.exchange).Msg("WebSocket connected")
-		if c.handlers.OnConnect != nil {
-			c.handlers.OnConnect(c.exchange, true)
-		}
-		c.markReady()
-	})
+// setupStreamHandlers sets up driver stream callbacks.
+func (c *Connector) setupStreamHandlers() {
+	c.driver.SetCallbacks(driver.Callbacks{
+		OnTicker: func(ticker *domain.Ticker) {
+			c.tickerFeed.publish(ticker)
+			if c.handlers.OnTicker != nil {
+				c.safeHandler(func() {
+					c.handlers.OnTicker(c.exchange, ticker)
+				})
+			}
+		},
+		OnOrderBook: func(ob *domain.OrderBook) {
+			c.orderBooks.set(ob)
+			c.orderBookFeed.publish(ob)
+			if c.handlers.OnOrderBook != nil {
+				c.safeHandler(func() {
+					c.handlers.OnOrderBook(c.exchange, ob)
+				})
+			}
+		},
+		OnBookResync: func(symbol string, reason error) {
+			c.orderBooks.invalidate(symbol)
+			log.Warn().Err(reason).Str("exchange", c.exchange).Str("symbol", symbol).Msg("order book resync")
+			if c.handlers.OnBookResync != nil {
+				c.safeHandler(func() {
+					c.handlers.OnBookResync(c.exchange, symbol, reason)
+				})
+			}
+		},
+		OnTrade: func(trade *domain.Trade) {
+			c.tradeFeed.publish(trade)
+			if c.handlers.OnTrade != nil {
+				c.safeHandler(func() {
+					c.handlers.OnTrade(c.exchange, trade)
+				})
+			}
+		},
+		OnKline: func(kline *domain.Kline) {
+			c.klineFeed.publish(kline)
+			if c.handlers.OnKline != nil {
+				c.safeHandler(func() {
+					c.handlers.OnKline(c.exchange, kline)
+				})
+			}
+		},
+		OnOrder: func(order *domain.Order) {
+			if c.deadMan != nil {
+				c.deadMan.Track(order.Symbol)
+			}
+			c.applyOrder(order)
+		},
+		OnBalance: func(balance *domain.Balance) {
+			if !c.balances.set(balance) {
+				return
+			}
+			if c.handlers.OnBalance != nil {
+				c.safeHandler(func() {
+					c.handlers.OnBalance(c.exchange, balance)
+				})
+			}
+		},
+		OnBalanceDelta: func(asset string, delta domain.Decimal, at time.Time) {
+			balance, ok := c.balances.applyDelta(c.exchange, asset, delta, at)
+			if !ok {
+				return
+			}
+			if c.handlers.OnBalance != nil {
+				c.safeHandler(func() {
+					c.handlers.OnBalance(c.exchange, &balance)
+				})
+			}
+		},
+		OnConnect: func() {
+			log.Info().Str("exchange", c.exchange).Msg("WebSocket connected")
+			if c.handlers.OnConnect != nil {
+				c.handlers.OnConnect(c.exchange, true)
+			}
+			c.markReady()
 
-	c.wsClient.OnDisconnect(func(err error) {
-		log.Error().Err(err).Str("exchange", c.exchange).Msg("WebSocket disconnected")
-		if c.handlers.OnDisconnect != nil {
-			c.handlers.OnDisconnect(c.exchange, false)
-		}
+			// Re-seed on every (re)connect: stream updates may have been missed
+			if c.hasCredentials() {
+				go c.seedBalances()
+			}
+			if c.reconciler != nil {
+				c.reconciler.request()
+			}
+		},
+		OnDisconnect: func(err error) {
+			log.Error().Err(err).Str("exchange", c.exchange).Msg("WebSocket disconnected")
+			if c.handlers.OnDisconnect != nil {
+				c.handlers.OnDisconnect(c.exchange, false)
+			}
+		},
+		OnUserDataConnect: func() {
+			log.Info().Str("exchange", c.exchange).Msg("user data stream connected")
+			if c.deadMan != nil {
+				c.deadMan.userDataConnected()
+				go c.armCancelCountdown()
+			}
+			if c.reconciler != nil {
+				c.reconciler.request()
+			}
+		},
+		OnUserDataDisconnect: func(err error) {
+			log.Warn().Err(err).Str("exchange", c.exchange).Msg("user data stream disconnected")
+			if c.deadMan != nil {
+				c.deadMan.userDataDisconnected()
+			}
+		},
+		OnError: func(err error) {
+			log.Error().Err(err).Str("exchange", c.exchange).Msg("driver error")
+			if c.handlers.OnError != nil {
+				c.safeHandler(func() {
+					c.handlers.OnError(c.exchange, err)
+				})
+			}
+		},
 	})
 }
 
@@ -188,9 +321,30 @@ func (c *Connector) Start() error {
 		})
 	}
 
+	// Watch for silence once running
+	if c.deadMan != nil {
+		c.wg.Go(func() {
+			c.deadMan.run(c.ctx)
+		})
+	}
+
+	// Reconcile order state after connects and on schedule
+	if c.reconciler != nil {
+		c.wg.Go(func() {
+			c.reconciler.run(c.ctx)
+		})
+	}
+
+	// Refresh symbols and their trading rules on schedule
+	if interval := c.config.Symbols.RefreshInterval; interval > 0 {
+		c.wg.Go(func() {
+			c.symbolInfo.run(c.ctx, interval)
+		})
+	}
+
 	// Connect WebSocket
 	c.wg.Go(func() {
-		if err := c.wsClient.Connect(); err != nil {
+		if err := c.driver.Connect(c.ctx); err != nil {
 			log.Error().Err(err).Msg("WebSocket connection failed")
 			if c.handlers.OnError != nil {
 				c.handlers.OnError(c.exchange, err)
@@ -220,9 +374,11 @@ func (c *Connector) Stop() error {
 		c.clockSync.Stop()
 	}
 
-	if c.wsClient != nil {
-		c.wsClient.Close()
-	}
+	// Close channel subscriptions
+	c.tickerFeed.closeAll()
+	c.orderBookFeed.closeAll()
+	c.tradeFeed.closeAll()
+	c.klineFeed.closeAll()
 
 	// Wait for goroutines
 	done := make(chan struct{})
@@ -237,9 +393,9 @@ func (c *Connector) Stop() error {
 		log.Warn().Msg("timeout waiting for goroutines to stop")
 	}
 
-	// Close REST client
-	if c.restClient != nil {
-		c.restClient.Close()
+	// Close driver
+	if err := c.driver.Close(); err != nil {
+		log.Warn().Err(err).Str("exchange", c.exchange).Msg("driver close failed")
 	}
 
 	log.Info().Str("exchange", c.exchange).Msg("connector stopped")
@@ -266,7 +422,13 @@ func (c *Connector) IsRunning() bool {
 
 // IsConnected returns true if WebSocket is connected.
 func (c *Connector) IsConnected() bool {
-	return c.wsClient != nil && c.wsClient.IsConnected()
+	return c.driver.IsConnected()
+}
+
+// Backoff reports whether requests are held back after a rate limit response
+// or IP ban. Each backoff is also reported through Handlers.OnError.
+func (c *Connector) Backoff() driver.BackoffStatus {
+	return c.driver.Backoff()
 }
 
 // Exchange returns the exchange name.
@@ -282,88 +444,551 @@ func (c *Connector) SetHandlers(handlers Handlers) {
 // SubscribeTicker subscribes to ticker updates for a symbol.
 // Returns an unsubscribe function.
 func (c *Connector) SubscribeTicker(symbol string) (func(), error) {
-	if !c.running.Load() {
-		return nil, fmt.Errorf("connector not running")
-	}
+	return c.subscribe(driver.Subscription{Channel: driver.ChannelTicker, Symbol: symbol})
+}
+
+// SubscribeOrderBook subscribes to order book updates for a symbol.
+// The book is maintained locally; every update delivers a complete, consistent
+// book and the latest one is available from OrderBooks.
+func (c *Connector) SubscribeOrderBook(symbol string) (func(), error) {
+	return c.subscribe(driver.Subscription{Channel: driver.ChannelOrderBook, Symbol: symbol})
+}
 
-	stream := binance.NewStreamBuilder(symbol).Ticker()
-	if err := c.wsClient.Subscribe(stream); err != nil {
+// OrderBooks returns the latest order book per subscribed symbol.
+func (c *Connector) OrderBooks() *OrderBookStore {
+	return c.orderBooks
+}
+
+// OrderBook returns the best depth levels per side of the latest book for symbol.
+// A depth of zero or less returns the full book.
+func (c *Connector) OrderBook(symbol string, depth int) (*domain.OrderBook, bool) {
+	return c.orderBooks.Top(symbol, depth)
+}
+
+// SymbolRegistry returns the registry converting between exchange and
+// normalized symbols, filled from exchange info.
+func (c *Connector) SymbolRegistry() *domain.SymbolRegistry {
+	return c.symbols
+}
+
+// SubscribeTrades subscribes to trade updates for a symbol.
+func (c *Connector) SubscribeTrades(symbol string) (func(), error) {
+	return c.subscribe(driver.Subscription{Channel: driver.ChannelTrade, Symbol: symbol})
+}
+
+// SubscribeKlines subscribes to kline updates for a symbol.
+// interval uses the normalized form (e.g., "1m", "1h", "1d").
+func (c *Connector) SubscribeKlines(symbol, interval string) (func(), error) {
+	return c.subscribe(driver.Subscription{Channel: driver.ChannelKline, Symbol: symbol, Interval: interval})
+}
+
+// SubscribeTickerChan subscribes to ticker updates for symbols and delivers
+// them on a channel instead of the read loop. OverflowConflate keeps only the
+// latest ticker per symbol.
+func (c *Connector) SubscribeTickerChan(opts SubscriptionOptions, symbols ...string) (*Subscription[*domain.Ticker], error) {
+	return subscribeChan(c, &c.tickerFeed, opts, driver.ChannelTicker, "", symbols,
+		func(t *domain.Ticker) string { return t.Symbol }, nil)
+}
+
+// SubscribeOrderBookChan subscribes to order book updates for symbols and
+// delivers them on a channel instead of the read loop. Every update is a
+// complete book, so OverflowConflate keeps only the latest book per symbol
+// without losing state.
+func (c *Connector) SubscribeOrderBookChan(opts SubscriptionOptions, symbols ...string) (*Subscription[*domain.OrderBook], error) {
+	return subscribeChan(c, &c.orderBookFeed, opts, driver.ChannelOrderBook, "", symbols,
+		func(ob *domain.OrderBook) string { return ob.Symbol }, nil)
+}
+
+// SubscribeTradeChan subscribes to trade updates for symbols and delivers
+// them on a channel instead of the read loop.
+// Every policy except OverflowBlock can lose trades.
+func (c *Connector) SubscribeTradeChan(opts SubscriptionOptions, symbols ...string) (*Subscription[*domain.Trade], error) {
+	return subscribeChan(c, &c.tradeFeed, opts, driver.ChannelTrade, "", symbols,
+		func(t *domain.Trade) string { return t.Symbol }, nil)
+}
+
+// SubscribeKlineChan subscribes to kline updates for symbols and delivers
+// them on a channel instead of the read loop.
+// interval uses the normalized form (e.g., "1m", "1h", "1d"). With
+// OverflowConflate, a closed kline can be replaced by the next open one.
+func (c *Connector) SubscribeKlineChan(opts SubscriptionOptions, interval string, symbols ...string) (*Subscription[*domain.Kline], error) {
+	return subscribeChan(c, &c.klineFeed, opts, driver.ChannelKline, interval, symbols,
+		func(k *domain.Kline) string { return k.Symbol },
+		func(k *domain.Kline) bool { return k.Interval == interval })
+}
+
+// subscribeChan subscribes to a channel for symbols and registers a channel
+// subscription with f. symbol returns the normalized symbol of an update;
+// filter, if set, further restricts the updates delivered.
+// If any stream fails to subscribe, those already subscribed are released.
+func subscribeChan[T any](c *Connector, f *feed[T], opts SubscriptionOptions, channel driver.Channel, interval string, symbols []string, symbol func(v T) string, filter func(v T) bool) (*Subscription[T], error) {
+	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
+	if len(symbols) == 0 {
+		return nil, errors.NewValidationError("symbols", symbols, "at least one symbol is required")
+	}
 
-	return func() {
-		c.wsClient.Unsubscribe(stream)
-	}, nil
+	wanted := make(map[string]bool, len(symbols))
+	releases := make([]func(), 0, len(symbols))
+	releaseAll := func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+	for _, name := range symbols {
+		name = c.symbols.Normalize(name)
+		if wanted[name] {
+			continue
+		}
+		wanted[name] = true
+
+		release, err := c.subscribe(driver.Subscription{Channel: channel, Symbol: name, Interval: interval})
+		if err != nil {
+			releaseAll()
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+
+	match := func(v T) bool {
+		return wanted[symbol(v)] && (filter == nil || filter(v))
+	}
+	s := newSubscription(opts, match, symbol)
+	s.release = func() {
+		f.remove(s)
+		releaseAll()
+	}
+	f.add(s)
+	return s, nil
 }
 
-// SubscribeOrderBook subscribes to order book updates for a symbol.
-func (c *Connector) SubscribeOrderBook(symbol string) (func(), error) {
+// subscribe adds a driver subscription and returns its unsubscribe function.
+// Streams are shared: the driver subscription is added by the first
+// subscriber and removed, with its order book, after the last unsubscribes.
+func (c *Connector) subscribe(sub driver.Subscription) (func(), error) {
 	if !c.running.Load() {
 		return nil, fmt.Errorf("connector not running")
 	}
+	sub.Symbol = c.symbols.Normalize(sub.Symbol)
 
-	stream := binance.NewStreamBuilder(symbol).Depth()
-	if err := c.wsClient.Subscribe(stream); err != nil {
-		return nil, err
+	c.streamsMu.Lock()
+	defer c.streamsMu.Unlock()
+
+	if c.streams[sub] == 0 {
+		if err := c.driver.Subscribe(sub); err != nil {
+			return nil, err
+		}
 	}
+	c.streams[sub]++
 
+	var once stdsync.Once
 	return func() {
-		c.wsClient.Unsubscribe(stream)
+		once.Do(func() {
+			c.unsubscribe(sub)
+		})
 	}, nil
 }
 
-// SubscribeTrades subscribes to trade updates for a symbol.
-func (c *Connector) SubscribeTrades(symbol string) (func(), error) {
-	if !c.running.Load() {
-		return nil, fmt.Errorf("connector not running")
+// unsubscribe releases one subscriber of a driver subscription.
+func (c *Connector) unsubscribe(sub driver.Subscription) {
+	c.streamsMu.Lock()
+	defer c.streamsMu.Unlock()
+
+	if c.streams[sub]--; c.streams[sub] > 0 {
+		return
 	}
+	delete(c.streams, sub)
 
-	stream := binance.NewStreamBuilder(symbol).Trade()
-	if err := c.wsClient.Subscribe(stream); err != nil {
-		return nil, err
+	if err := c.driver.Unsubscribe(sub); err != nil {
+		log.Warn().Err(err).Str("exchange", c.exchange).Str("symbol", sub.Symbol).Msg("unsubscribe failed")
+	}
+	if sub.Channel == driver.ChannelOrderBook {
+		c.orderBooks.remove(sub.Symbol)
 	}
+}
 
-	return func() {
-		c.wsClient.Unsubscribe(stream)
-	}, nil
+// Orders returns the local order store.
+// It tracks orders placed, cancelled or queried through the connector and
+// orders reported on the user data stream. With reconciliation enabled,
+// closed orders are pruned a day after their last update.
+func (c *Connector) Orders() *OrderStore {
+	return c.orders
+}
+
+// Reconcile reconciles the order store with the exchange now, instead of
+// waiting for the next scheduled run.
+func (c *Connector) Reconcile(ctx context.Context) error {
+	if c.reconciler == nil {
+		return fmt.Errorf("order reconciliation not enabled")
+	}
+	return c.reconciler.Reconcile(ctx)
+}
+
+// Reconciler returns the order reconciler, or nil if it is not enabled.
+func (c *Connector) Reconciler() *Reconciler {
+	return c.reconciler
+}
+
+// Balances returns the in-memory balance book.
+// The book is seeded from GetBalances on connect and kept current from the
+// user data stream. It stays empty without API credentials.
+func (c *Connector) Balances() *BalanceBook {
+	return c.balances
+}
+
+// SyncBalances reloads the balance book from a REST snapshot.
+func (c *Connector) SyncBalances(ctx context.Context) error {
+	balances, err := c.GetBalances(ctx)
+	if err != nil {
+		return err
+	}
+	c.balances.reset(balances)
+	return nil
+}
+
+// seedBalances syncs the balance book in the background and reports failures.
+func (c *Connector) seedBalances() {
+	if err := c.SyncBalances(c.ctx); err != nil {
+		if c.ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Str("exchange", c.exchange).Msg("balance sync failed")
+		if c.handlers.OnError != nil {
+			c.safeHandler(func() {
+				c.handlers.OnError(c.exchange, err)
+			})
+		}
+	}
+}
+
+// trackOrder applies a REST order response to the order store.
+func (c *Connector) trackOrder(order *domain.Order, err error) (*domain.Order, error) {
+	if err == nil {
+		c.applyOrder(order)
+	}
+	return order, err
+}
+
+// cancelAllTracked cancels all open orders on symbol for the dead-man's
+// switch, bypassing the circuit breaker. The cancelled orders are applied to
+// the order store, since the switch fires while stream updates are missing.
+func (c *Connector) cancelAllTracked(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	return c.trackOrders(c.driver.CancelAllOrders(ctx, symbol))
+}
+
+// trackOrders applies a list of REST order responses to the order store.
+func (c *Connector) trackOrders(orders []*domain.Order, err error) ([]*domain.Order, error) {
+	if err == nil {
+		for _, order := range orders {
+			c.applyOrder(order)
+		}
+	}
+	return orders, err
+}
+
+// applyOrder applies an order update to the order store and forwards the
+// result to the handler. Stale and duplicate updates are dropped; illegal
+// transitions are reported as errors.
+func (c *Connector) applyOrder(order *domain.Order) {
+	applied, err := c.orders.apply(order)
+	if err != nil {
+		log.Warn().Err(err).Str("exchange", c.exchange).Str("order_id", order.ID).Msg("order update rejected")
+		if c.handlers.OnError != nil {
+			c.safeHandler(func() {
+				c.handlers.OnError(c.exchange, err)
+			})
+		}
+		return
+	}
+	c.emitOrder(applied)
+}
+
+// emitOrder forwards an order accepted by the order store to the handler.
+// Nil orders (stale or duplicate updates) are ignored.
+func (c *Connector) emitOrder(order *domain.Order) {
+	if order != nil && c.handlers.OnOrder != nil {
+		c.safeHandler(func() {
+			c.handlers.OnOrder(c.exchange, order)
+		})
+	}
+}
+
+// hasCredentials returns true if API credentials are configured.
+func (c *Connector) hasCredentials() bool {
+	return c.config.Exchange.APIKey != "" && c.config.Exchange.APISecret != ""
 }
 
 // Ping tests REST connectivity.
 func (c *Connector) Ping(ctx context.Context) error {
 	if c.circuitBreaker != nil {
 		return c.circuitBreaker.Execute(func() error {
-			return c.restClient.Ping(ctx)
+			return c.driver.Ping(ctx)
 		})
 	}
-	return c.restClient.Ping(ctx)
+	return c.driver.Ping(ctx)
 }
 
 // GetServerTime retrieves the exchange server time.
 func (c *Connector) GetServerTime(ctx context.Context) (int64, error) {
 	if c.circuitBreaker != nil {
 		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
-			return c.restClient.GetServerTime(ctx)
+			return c.driver.GetServerTime(ctx)
 		})
 		if err != nil {
 			return 0, err
 		}
 		return result.(int64), nil
 	}
-	return c.restClient.GetServerTime(ctx)
+	return c.driver.GetServerTime(ctx)
 }
 
 // GetExchangeInfo retrieves exchange trading rules.
-func (c *Connector) GetExchangeInfo(ctx context.Context) (*binance.ExchangeInfo, error) {
+// Every call requests the full listing from the exchange (weight 20 on
+// Binance); use Symbols and Symbol for cached lookups.
+func (c *Connector) GetExchangeInfo(ctx context.Context) (*domain.ExchangeInfo, error) {
+	return c.exchangeInfo(ctx, driver.ExchangeInfoQuery{})
+}
+
+// exchangeInfo retrieves exchange trading rules for the symbols selected by query.
+func (c *Connector) exchangeInfo(ctx context.Context, query driver.ExchangeInfoQuery) (*domain.ExchangeInfo, error) {
 	if c.circuitBreaker != nil {
 		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
-			return c.restClient.GetExchangeInfo(ctx)
+			return c.driver.GetExchangeInfo(ctx, query)
 		})
 		if err != nil {
 			return nil, err
 		}
-		return result.(*binance.ExchangeInfo), nil
+		return result.(*domain.ExchangeInfo), nil
+	}
+	return c.driver.GetExchangeInfo(ctx, query)
+}
+
+// Symbols returns the info and trading rules of every listed symbol, sorted
+// by symbol.
+// The listing is cached: it is loaded on first use and reloaded once older
+// than SymbolsConfig.TTL, with concurrent callers sharing one request. If a
+// reload fails, the previous listing is returned.
+//
+// IMPORTANT: Slices, filters and decimal values are shared with the cache
+// and must not be modified.
+func (c *Connector) Symbols(ctx context.Context) ([]domain.SymbolInfo, error) {
+	symbols, err := c.symbolInfo.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]domain.SymbolInfo, 0, len(symbols))
+	for _, info := range symbols {
+		infos = append(infos, *info)
+	}
+	slices.SortFunc(infos, func(a, b domain.SymbolInfo) int {
+		return cmp.Compare(a.Symbol, b.Symbol)
+	})
+	return infos, nil
+}
+
+// Symbol returns the info and trading rules of a symbol in normalized or
+// exchange format, from the same cache as Symbols.
+// Returns a *errors.NotFoundError if the symbol is not listed.
+func (c *Connector) Symbol(ctx context.Context, symbol string) (*domain.SymbolInfo, error) {
+	info, ok, err := c.symbolInfo.get(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.NewNotFoundError("symbol", symbol)
 	}
-	return c.restClient.GetExchangeInfo(ctx)
+	result := *info
+	return &result, nil
+}
+
+// RefreshSymbols reloads symbol info now, instead of waiting for the TTL or
+// the next scheduled refresh. A zero query reloads the full listing; a query
+// with symbols or permissions reloads only the symbols it selects.
+// Status changes are reported to OnSymbolStatus.
+func (c *Connector) RefreshSymbols(ctx context.Context, query driver.ExchangeInfoQuery) error {
+	return c.symbolInfo.refreshPartial(ctx, query)
+}
+
+// GetKlines retrieves the klines opened between start and end, oldest first.
+// Long ranges are fetched page by page within the exchange rate limits.
+// A zero end means up to the latest kline; a zero start returns only the
+// latest page ending at end.
+func (c *Connector) GetKlines(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.GetKlines(ctx, symbol, interval, start, end)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.([]domain.Kline), nil
+	}
+	return c.driver.GetKlines(ctx, symbol, interval, start, end)
+}
+
+// GetBalances retrieves all non-empty account balances.
+func (c *Connector) GetBalances(ctx context.Context) ([]domain.Balance, error) {
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.GetBalances(ctx)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.([]domain.Balance), nil
+	}
+	return c.driver.GetBalances(ctx)
+}
+
+// PlaceOrder submits a new order.
+// If req.Exchange is empty it is set to the connector's exchange.
+// The order is checked with ValidateOrder first and not sent if it fails.
+func (c *Connector) PlaceOrder(ctx context.Context, req *domain.OrderRequest) (*domain.Order, error) {
+	if req.Exchange == "" {
+		req.Exchange = c.exchange
+	}
+	if req.Exchange != c.exchange {
+		return nil, errors.NewValidationError("exchange", req.Exchange, "does not match connector exchange")
+	}
+
+	if err := c.ValidateOrder(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Tracked before sending: the order may rest even if the response is lost
+	if c.deadMan != nil {
+		c.deadMan.Track(req.Symbol)
+	}
+
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.PlaceOrder(ctx, req)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.trackOrder(result.(*domain.Order), nil)
+	}
+	return c.trackOrder(c.driver.PlaceOrder(ctx, req))
+}
+
+// ValidateOrder checks an order request against the symbol filters, so
+// orders the exchange would reject (e.g., Binance -1013) are not sent.
+// Violations are returned as *errors.ValidationError naming the field; see
+// domain.SymbolFilters.ValidateOrder. The percent price filter and the
+// notional of market orders use the order book mid price as the reference
+// price, and are skipped without a book for the symbol.
+//
+// Filters come from the Symbols cache. If they cannot be loaded, or the
+// symbol is not listed, the order is passed through and left to the exchange.
+func (c *Connector) ValidateOrder(ctx context.Context, req *domain.OrderRequest) error {
+	info, ok, err := c.symbolInfo.get(ctx, req.Symbol)
+	if err != nil {
+		log.Warn().Err(err).Str("exchange", c.exchange).Msg("symbol filters unavailable, order not validated")
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+
+	fc := domain.FilterContext{
+		OpenOrders: len(c.orders.Open(req.Symbol)),
+	}
+	if book, ok := c.orderBooks.Get(req.Symbol); ok {
+		fc.ReferencePrice = book.MidPrice()
+	}
+	return info.Filters.ValidateOrder(req, fc)
+}
+
+// Quantizer returns a quantizer for symbol built from its filters.
+// Returns a *errors.NotFoundError if the symbol is not listed.
+func (c *Connector) Quantizer(ctx context.Context, symbol string) (*domain.Quantizer, error) {
+	info, err := c.Symbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewQuantizer(info), nil
+}
+
+// CancelOrder cancels an order by order ID or client order ID.
+// If req.Exchange is empty it is set to the connector's exchange.
+func (c *Connector) CancelOrder(ctx context.Context, req *domain.CancelRequest) (*domain.Order, error) {
+	if req.Exchange == "" {
+		req.Exchange = c.exchange
+	}
+	if req.Exchange != c.exchange {
+		return nil, errors.NewValidationError("exchange", req.Exchange, "does not match connector exchange")
+	}
+
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.CancelOrder(ctx, req)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.trackOrder(result.(*domain.Order), nil)
+	}
+	return c.trackOrder(c.driver.CancelOrder(ctx, req))
+}
+
+// CancelAllOrders cancels all open orders for a symbol.
+func (c *Connector) CancelAllOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.CancelAllOrders(ctx, symbol)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.trackOrders(result.([]*domain.Order), nil)
+	}
+	return c.trackOrders(c.driver.CancelAllOrders(ctx, symbol))
+}
+
+// GetOrder retrieves an order by exchange order ID.
+func (c *Connector) GetOrder(ctx context.Context, symbol, orderID string) (*domain.Order, error) {
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.GetOrder(ctx, symbol, orderID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.trackOrder(result.(*domain.Order), nil)
+	}
+	return c.trackOrder(c.driver.GetOrder(ctx, symbol, orderID))
+}
+
+// GetOpenOrders retrieves open orders. An empty symbol returns all symbols.
+func (c *Connector) GetOpenOrders(ctx context.Context, symbol string) ([]*domain.Order, error) {
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.GetOpenOrders(ctx, symbol)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.trackOrders(result.([]*domain.Order), nil)
+	}
+	return c.trackOrders(c.driver.GetOpenOrders(ctx, symbol))
+}
+
+// GetAllOrders retrieves order history for a symbol.
+// Zero times and limit use the exchange defaults.
+func (c *Connector) GetAllOrders(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.Order, error) {
+	if c.circuitBreaker != nil {
+		result, err := c.circuitBreaker.ExecuteWithResult(func() (any, error) {
+			return c.driver.GetAllOrders(ctx, symbol, startTime, endTime, limit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.([]*domain.Order), nil
+	}
+	return c.driver.GetAllOrders(ctx, symbol, startTime, endTime, limit)
 }
 
 // CircuitBreakerStats returns circuit breaker statistics.
@@ -382,6 +1007,132 @@ func (c *Connector) ClockOffset() time.Duration {
 	return c.clockSync.Offset()
 }
 
+// ClockStats returns the quality of the clock offset estimate.
+func (c *Connector) ClockStats() (driver.ClockStats, error) {
+	if c.clockSync == nil {
+		return driver.ClockStats{}, fmt.Errorf("clock sync not enabled")
+	}
+	stats := c.clockSync.Stats()
+	return driver.ClockStats{
+		Offset:     stats.Offset,
+		DriftPPM:   stats.DriftPPM,
+		RTT:        stats.RTT,
+		Jitter:     stats.Jitter,
+		ErrorBound: stats.ErrorBound,
+		Confidence: stats.Confidence,
+		Samples:    stats.Samples,
+		Accepted:   stats.Accepted,
+		LastSync:   stats.LastSync,
+	}, nil
+}
+
+// Heartbeat tells the dead-man's switch the application is alive.
+// With DeadMan.HeartbeatTimeout set, it must be called more often than the
+// timeout or all open orders are cancelled. No-op without the switch.
+func (c *Connector) Heartbeat() {
+	if c.deadMan != nil {
+		c.deadMan.Heartbeat()
+	}
+}
+
+// DeadMan returns the dead-man's switch, or nil if it is not enabled.
+func (c *Connector) DeadMan() *DeadManSwitch {
+	return c.deadMan
+}
+
+// armCancelCountdown arms the exchange-side countdown cancel where the driver
+// supports one. Re-armed on every user data connect, since it is tied to the
+// authenticated connection.
+func (c *Connector) armCancelCountdown() {
+	countdown, ok := c.driver.(driver.CancelCountdown)
+	window := cmp.Or(c.config.DeadMan.NativeWindow, c.config.DeadMan.DisconnectTimeout)
+	if !ok || window <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.config.Connection.Timeout)
+	defer cancel()
+
+	err := countdown.ArmCancelCountdown(ctx, window)
+	c.deadMan.setNative(err == nil)
+	if err != nil && c.ctx.Err() == nil {
+		log.Error().Err(err).Str("exchange", c.exchange).Msg("cancel countdown arm failed")
+		if c.handlers.OnError != nil {
+			c.safeHandler(func() {
+				c.handlers.OnError(c.exchange, err)
+			})
+		}
+	}
+}
+
+// reportDeadMan logs a dead-man's switch trigger and forwards it to the handler.
+func (c *Connector) reportDeadMan(trigger *DeadManTrigger) {
+	log.Warn().
+		Str("exchange", c.exchange).
+		Str("reason", string(trigger.Reason)).
+		Dur("silence", trigger.Silence).
+		Strs("symbols", trigger.Symbols).
+		Int("cancelled", len(trigger.Cancelled)).
+		Int("failed", len(trigger.Errors)).
+		Msg("dead-man's switch triggered")
+	if c.handlers.OnDeadMan != nil {
+		c.safeHandler(func() {
+			c.handlers.OnDeadMan(c.exchange, trigger)
+		})
+	}
+}
+
+// reportDiscrepancy logs a reconciliation discrepancy and forwards it to the handler.
+func (c *Connector) reportDiscrepancy(discrepancy *Discrepancy) {
+	log.Warn().
+		Err(discrepancy.Err).
+		Str("exchange", c.exchange).
+		Str("kind", string(discrepancy.Kind)).
+		Str("symbol", discrepancy.Symbol).
+		Str("order_id", discrepancy.OrderID).
+		Msg("order discrepancy")
+	if c.handlers.OnDiscrepancy != nil {
+		c.safeHandler(func() {
+			c.handlers.OnDiscrepancy(c.exchange, discrepancy)
+		})
+	}
+}
+
+// reportReconcileError logs a failed scheduled reconciliation and forwards it to the handler.
+func (c *Connector) reportReconcileError(err error) {
+	log.Error().Err(err).Str("exchange", c.exchange).Msg("order reconciliation failed")
+	if c.handlers.OnError != nil {
+		c.safeHandler(func() {
+			c.handlers.OnError(c.exchange, err)
+		})
+	}
+}
+
+// reportSymbolStatus logs a symbol status change and forwards it to the handler.
+func (c *Connector) reportSymbolStatus(change *SymbolStatusChange) {
+	log.Info().
+		Str("exchange", c.exchange).
+		Str("symbol", change.Symbol).
+		Str("previous", change.Previous).
+		Str("current", change.Current).
+		Msg("symbol status changed")
+	if c.handlers.OnSymbolStatus != nil {
+		c.safeHandler(func() {
+			c.handlers.OnSymbolStatus(c.exchange, change)
+		})
+	}
+}
+
+// reportSymbolsError logs a failed symbol refresh and forwards it to the handler.
+func (c *Connector) reportSymbolsError(err error) {
+	log.Error().Err(err).Str("exchange", c.exchange).Msg("symbol refresh failed")
+	if c.handlers.OnError != nil {
+		c.safeHandler(func() {
+			c.handlers.OnError(c.exchange, err)
+		})
+	}
+}
+
 // safeHandler executes a handler with panic recovery.
 func (c *Connector) safeHandler(fn func()) {
 	defer func() {
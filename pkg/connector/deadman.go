@@ -0,0 +1,231 @@
+package connector
+
+import (
+	"context"
+	"slices"
+	stdsync "sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+)
+
+// deadManCancelTimeout bounds the cancel-all requests of one trigger.
+const deadManCancelTimeout = 10 * time.Second
+
+// DeadManReason identifies what fired the dead-man's switch.
+type DeadManReason string
+
+const (
+	// DeadManDisconnect: the user data stream was down longer than DisconnectTimeout.
+	DeadManDisconnect DeadManReason = "user_data_disconnected"
+	// DeadManHeartbeat: Heartbeat was not called within HeartbeatTimeout.
+	DeadManHeartbeat DeadManReason = "heartbeat_missed"
+)
+
+// DeadManTrigger describes one firing of the dead-man's switch.
+type DeadManTrigger struct {
+	Reason    DeadManReason
+	At        time.Time
+	Silence   time.Duration    // How long the stream was down or the heartbeat missing
+	Symbols   []string         // Symbols cancel-all was sent for
+	Cancelled []*domain.Order  // Orders reported cancelled by the exchange
+	Errors    map[string]error // Cancel-all failures by symbol
+	Native    bool             // An exchange-side countdown was armed as well
+}
+
+// DeadManSwitch cancels all open orders when the application or its user
+// data stream goes silent, so resting orders are not left unattended.
+//
+// Cancel-all is sent for every tracked symbol. Symbols are tracked
+// automatically when orders are placed or order updates arrive, and can be
+// added with Track for orders placed elsewhere.
+//
+// Each trigger fires once and re-arms when its condition clears: the user
+// data stream reconnects, or Heartbeat is called again.
+// All methods are safe for concurrent use.
+type DeadManSwitch struct {
+	config    DeadManConfig
+	cancelAll func(ctx context.Context, symbol string) ([]*domain.Order, error)
+	onTrigger func(trigger *DeadManTrigger)
+	registry  *domain.SymbolRegistry
+
+	mu        stdsync.Mutex
+	symbols   map[string]struct{}
+	connected bool
+	downSince time.Time // Zero while the user data stream is connected
+	lastBeat  time.Time
+	fired     map[DeadManReason]bool // Latched until the condition clears
+	native    bool
+}
+
+// newDeadManSwitch creates a dead-man's switch.
+// cancelAll is called for each tracked symbol when a trigger fires; registry
+// normalizes the symbols passed to Track and Untrack.
+func newDeadManSwitch(cfg DeadManConfig, registry *domain.SymbolRegistry, cancelAll func(ctx context.Context, symbol string) ([]*domain.Order, error), onTrigger func(trigger *DeadManTrigger)) *DeadManSwitch {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = DefaultDeadManConfig().CheckInterval
+	}
+
+	return &DeadManSwitch{
+		config:    cfg,
+		cancelAll: cancelAll,
+		onTrigger: onTrigger,
+		registry:  registry,
+		symbols:   make(map[string]struct{}),
+		fired:     make(map[DeadManReason]bool),
+	}
+}
+
+// Track adds a symbol to cancel on trigger.
+func (d *DeadManSwitch) Track(symbol string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.symbols[d.registry.Normalize(symbol)] = struct{}{}
+}
+
+// Untrack removes a symbol.
+func (d *DeadManSwitch) Untrack(symbol string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.symbols, d.registry.Normalize(symbol))
+}
+
+// Symbols returns the tracked symbols, sorted.
+func (d *DeadManSwitch) Symbols() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.sortedSymbols()
+}
+
+// sortedSymbols returns the tracked symbols. Caller must hold mu.
+func (d *DeadManSwitch) sortedSymbols() []string {
+	symbols := make([]string, 0, len(d.symbols))
+	for symbol := range d.symbols {
+		symbols = append(symbols, symbol)
+	}
+	slices.Sort(symbols)
+	return symbols
+}
+
+// Heartbeat records that the application is alive and re-arms the heartbeat trigger.
+func (d *DeadManSwitch) Heartbeat() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lastBeat = time.Now()
+	d.fired[DeadManHeartbeat] = false
+}
+
+// userDataConnected records that the user data stream is up and re-arms the disconnect trigger.
+func (d *DeadManSwitch) userDataConnected() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.connected = true
+	d.downSince = time.Time{}
+	d.fired[DeadManDisconnect] = false
+}
+
+// userDataDisconnected records that the user data stream went down.
+func (d *DeadManSwitch) userDataDisconnected() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.connected = false
+	if d.downSince.IsZero() {
+		d.downSince = time.Now()
+	}
+}
+
+// setNative records whether an exchange-side countdown is armed.
+func (d *DeadManSwitch) setNative(native bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.native = native
+}
+
+// run checks the triggers every CheckInterval until ctx is done.
+// Both timeouts start counting when run starts; the user data stream counts
+// as down until its first connect.
+func (d *DeadManSwitch) run(ctx context.Context) {
+	d.mu.Lock()
+	now := time.Now()
+	d.lastBeat = now
+	if d.downSince.IsZero() && !d.connected {
+		d.downSince = now
+	}
+	d.mu.Unlock()
+
+	ticker := time.NewTicker(d.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, trigger := range d.check(now) {
+				d.fire(ctx, trigger)
+			}
+		}
+	}
+}
+
+// check returns the triggers that fire at now and latches them.
+func (d *DeadManSwitch) check(now time.Time) []*DeadManTrigger {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var triggers []*DeadManTrigger
+	add := func(reason DeadManReason, silence time.Duration) {
+		d.fired[reason] = true
+		triggers = append(triggers, &DeadManTrigger{
+			Reason:  reason,
+			At:      now,
+			Silence: silence,
+			Symbols: d.sortedSymbols(),
+			Native:  d.native,
+		})
+	}
+
+	if timeout := d.config.DisconnectTimeout; timeout > 0 && !d.downSince.IsZero() && !d.fired[DeadManDisconnect] {
+		if silence := now.Sub(d.downSince); silence >= timeout {
+			add(DeadManDisconnect, silence)
+		}
+	}
+	if timeout := d.config.HeartbeatTimeout; timeout > 0 && !d.fired[DeadManHeartbeat] {
+		if silence := now.Sub(d.lastBeat); silence >= timeout {
+			add(DeadManHeartbeat, silence)
+		}
+	}
+	return triggers
+}
+
+// fire sends cancel-all for every symbol of the trigger and reports it.
+// Cancels run with their own timeout so they still go out while the
+// connector is stopping.
+func (d *DeadManSwitch) fire(ctx context.Context, trigger *DeadManTrigger) {
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadManCancelTimeout)
+	defer cancel()
+
+	var mu stdsync.Mutex
+	var wg stdsync.WaitGroup
+	for _, symbol := range trigger.Symbols {
+		wg.Go(func() {
+			orders, err := d.cancelAll(cancelCtx, symbol)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if trigger.Errors == nil {
+					trigger.Errors = make(map[string]error)
+				}
+				trigger.Errors[symbol] = err
+				return
+			}
+			trigger.Cancelled = append(trigger.Cancelled, orders...)
+		})
+	}
+	wg.Wait()
+
+	if d.onTrigger != nil {
+		d.onTrigger(trigger)
+	}
+}
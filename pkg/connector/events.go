@@ -8,11 +8,15 @@ import (
 type EventType string
 
 const (
-	EventTicker    EventType = "ticker"
-	EventOrderBook EventType = "orderbook"
-	EventTrade     EventType = "trade"
-	EventOrder     EventType = "order"
-	EventBalance   EventType = "balance"
+	EventTicker       EventType = "ticker"
+	EventOrderBook    EventType = "orderbook"
+	EventTrade        EventType = "trade"
+	EventKline        EventType = "kline"
+	EventOrder        EventType = "order"
+	EventBalance      EventType = "balance"
+	EventDeadMan      EventType = "deadman"
+	EventDiscrepancy  EventType = "discrepancy"
+	EventSymbolStatus EventType = "symbol_status"
 )
 
 // Event represents an event from the exchange.
@@ -31,22 +35,58 @@ type OrderBookHandler func(exchange string, orderbook *domain.OrderBook)
 // TradeHandler handles trade events.
 type TradeHandler func(exchange string, trade *domain.Trade)
 
+// KlineHandler handles kline events.
+// Updates for the current kline are delivered until IsClosed is true.
+type KlineHandler func(exchange string, kline *domain.Kline)
+
 // OrderHandler handles order update events.
+// The order is its merged state after the update, from the stream or from a
+// REST response. Fills holds only the fills new in this update; duplicate and
+// stale updates are not delivered.
 type OrderHandler func(exchange string, order *domain.Order)
 
+// BalanceHandler handles balance update events.
+// The balance is the asset's full state after the update, not a delta.
+type BalanceHandler func(exchange string, balance *domain.Balance)
+
+// BookResyncHandler handles order book resync events.
+// The book for symbol is invalid until a fresh snapshot is applied;
+// reason is typically a *errors.SequenceGapError.
+type BookResyncHandler func(exchange, symbol string, reason error)
+
+// DeadManHandler handles dead-man's switch triggers.
+// It is called after the cancel-all requests have completed.
+type DeadManHandler func(exchange string, trigger *DeadManTrigger)
+
+// DiscrepancyHandler handles order state the reconciler could not explain.
+// Updates it could explain are delivered to OnOrder instead.
+type DiscrepancyHandler func(exchange string, discrepancy *Discrepancy)
+
+// SymbolStatusHandler handles symbol status changes found when symbols are
+// refreshed, e.g. a symbol moving to BREAK or being delisted.
+type SymbolStatusHandler func(exchange string, change *SymbolStatusChange)
+
 // ConnectionHandler handles connection state changes.
 type ConnectionHandler func(exchange string, connected bool)
 
 // ErrorHandler handles errors.
+// Rate limit backoffs and IP bans are reported here as *errors.RateLimitError
+// and *errors.IPBanError when they start; see Connector.Backoff for their state.
 type ErrorHandler func(exchange string, err error)
 
 // Handlers contains all event handlers.
 type Handlers struct {
-	OnTicker     TickerHandler
-	OnOrderBook  OrderBookHandler
-	OnTrade      TradeHandler
-	OnOrder      OrderHandler
-	OnConnect    ConnectionHandler
-	OnDisconnect ConnectionHandler
-	OnError      ErrorHandler
+	OnTicker       TickerHandler
+	OnOrderBook    OrderBookHandler
+	OnBookResync   BookResyncHandler
+	OnTrade        TradeHandler
+	OnKline        KlineHandler
+	OnOrder        OrderHandler
+	OnBalance      BalanceHandler
+	OnConnect      ConnectionHandler
+	OnDisconnect   ConnectionHandler
+	OnError        ErrorHandler
+	OnDeadMan      DeadManHandler
+	OnDiscrepancy  DiscrepancyHandler
+	OnSymbolStatus SymbolStatusHandler
 }
@@ -0,0 +1,103 @@
+package connector
+
+import (
+	"sort"
+	stdsync "sync"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+)
+
+// OrderBookStore holds the latest consistent order book per symbol.
+// Books are published by the driver after each update is applied to its
+// locally maintained book, so every stored book is complete.
+// All methods are safe for concurrent use.
+//
+// IMPORTANT: Returned books are shared snapshots and must not be modified.
+type OrderBookStore struct {
+	symbols *domain.SymbolRegistry
+
+	mu      stdsync.RWMutex
+	books   map[string]*domain.OrderBook // Keyed by normalized symbol
+	resyncs map[string]int64
+	total   int64
+}
+
+// NewOrderBookStore creates an empty store.
+// symbols converts the exchange symbols accepted by its methods.
+func NewOrderBookStore(symbols *domain.SymbolRegistry) *OrderBookStore {
+	return &OrderBookStore{
+		symbols: symbols,
+		books:   make(map[string]*domain.OrderBook),
+		resyncs: make(map[string]int64),
+	}
+}
+
+// Get returns the latest book for symbol.
+func (s *OrderBookStore) Get(symbol string) (*domain.OrderBook, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	book, ok := s.books[s.symbols.Normalize(symbol)]
+	return book, ok
+}
+
+// Top returns the best depth levels per side of the latest book for symbol.
+func (s *OrderBookStore) Top(symbol string, depth int) (*domain.OrderBook, bool) {
+	book, ok := s.Get(symbol)
+	if !ok {
+		return nil, false
+	}
+	return book.Top(depth), true
+}
+
+// Symbols returns the symbols with a book, sorted.
+func (s *OrderBookStore) Symbols() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	symbols := make([]string, 0, len(s.books))
+	for symbol := range s.books {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Resyncs returns the number of times symbol's book was invalidated and resynced.
+func (s *OrderBookStore) Resyncs(symbol string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.resyncs[s.symbols.Normalize(symbol)]
+}
+
+// TotalResyncs returns the number of resyncs across all symbols.
+func (s *OrderBookStore) TotalResyncs() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.total
+}
+
+// set stores a book snapshot.
+func (s *OrderBookStore) set(book *domain.OrderBook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.books[s.symbols.Normalize(book.Symbol)] = book
+}
+
+// invalidate drops symbol's book until the next snapshot and counts the resync.
+func (s *OrderBookStore) invalidate(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.symbols.Normalize(symbol)
+	delete(s.books, key)
+	s.resyncs[key]++
+	s.total++
+}
+
+// remove deletes the book for symbol.
+func (s *OrderBookStore) remove(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.books, s.symbols.Normalize(symbol))
+}
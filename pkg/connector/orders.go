@@ -0,0 +1,365 @@
+package connector
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	stdsync "sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/util"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// OrderStore tracks the state of orders placed or reported on the account.
+// REST responses and user data stream updates are merged into one view per
+// order, keyed by exchange order ID and client order ID.
+// All methods are safe for concurrent use.
+//
+// Updates are applied in order and checked against the order state machine
+// (domain.OrderStatus.CanTransition):
+//   - An update with a lower filled quantity, or an earlier status that is
+//     older than the stored state, is stale and dropped
+//   - An update that repeats the stored status and filled quantity is a
+//     duplicate and dropped, as are fills whose trade ID was already applied
+//   - Any other illegal transition is rejected with a *errors.ValidationError
+//
+// IMPORTANT: Returned orders are copies, but their decimal values are shared
+// and must not be modified. Closed orders are kept until Prune removes them.
+type OrderStore struct {
+	symbols *domain.SymbolRegistry
+
+	mu        stdsync.RWMutex
+	orders    map[string]*trackedOrder // Keyed by exchange order ID
+	clientIDs map[string]string        // Client order ID to exchange order ID
+}
+
+// trackedOrder is the merged state of one order.
+type trackedOrder struct {
+	order  *domain.Order       // Fills holds every fill seen
+	trades map[string]struct{} // Trade IDs already applied
+}
+
+// NewOrderStore creates an empty order store.
+// symbols converts the exchange symbols accepted by its methods.
+func NewOrderStore(symbols *domain.SymbolRegistry) *OrderStore {
+	return &OrderStore{
+		symbols:   symbols,
+		orders:    make(map[string]*trackedOrder),
+		clientIDs: make(map[string]string),
+	}
+}
+
+// Get returns an order by exchange order ID.
+func (s *OrderStore) Get(orderID string) (*domain.Order, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tracked, ok := s.orders[orderID]
+	if !ok {
+		return nil, false
+	}
+	return copyOrder(tracked.order), true
+}
+
+// GetByClientID returns an order by client order ID.
+func (s *OrderStore) GetByClientID(clientOrderID string) (*domain.Order, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tracked := s.lookup("", clientOrderID)
+	if tracked == nil {
+		return nil, false
+	}
+	return copyOrder(tracked.order), true
+}
+
+// Open returns the open orders for symbol, oldest first.
+// An empty symbol returns open orders for all symbols.
+func (s *OrderStore) Open(symbol string) []*domain.Order {
+	if symbol != "" {
+		symbol = s.symbols.Normalize(symbol)
+	}
+	return s.filter(func(order *domain.Order) bool {
+		return order.IsOpen() && (symbol == "" || order.Symbol == symbol)
+	})
+}
+
+// All returns every tracked order, oldest first.
+func (s *OrderStore) All() []*domain.Order {
+	return s.filter(func(*domain.Order) bool { return true })
+}
+
+// Prune removes closed orders last updated before the given time.
+// Returns the number of orders removed.
+func (s *OrderStore) Prune(before time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, tracked := range s.orders {
+		if tracked.order.IsOpen() || !tracked.order.UpdatedAt.Before(before) {
+			continue
+		}
+		if tracked.order.ClientOrderID != "" {
+			delete(s.clientIDs, tracked.order.ClientOrderID)
+		}
+		delete(s.orders, id)
+		removed++
+	}
+	return removed
+}
+
+// hasTrades reports whether the order is tracked and every trade in trades
+// was already applied to it.
+func (s *OrderStore) hasTrades(orderID string, trades []domain.Trade) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tracked, ok := s.orders[orderID]
+	if !ok {
+		return false
+	}
+	for _, trade := range trades {
+		if _, seen := tracked.trades[trade.ID]; !seen {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns copies of the orders matching keep, sorted by creation time.
+func (s *OrderStore) filter(keep func(order *domain.Order) bool) []*domain.Order {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var orders []*domain.Order
+	for _, tracked := range s.orders {
+		if keep(tracked.order) {
+			orders = append(orders, copyOrder(tracked.order))
+		}
+	}
+	slices.SortFunc(orders, func(a, b *domain.Order) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return orders
+}
+
+// lookup finds a tracked order by exchange order ID, then by client order ID.
+// Caller must hold mu.
+func (s *OrderStore) lookup(orderID, clientOrderID string) *trackedOrder {
+	if tracked, ok := s.orders[orderID]; ok && orderID != "" {
+		return tracked
+	}
+	if id, ok := s.clientIDs[clientOrderID]; ok && clientOrderID != "" {
+		return s.orders[id]
+	}
+	return nil
+}
+
+// apply merges an order update into the store.
+// It returns the merged order with Fills set to only the fills new in this
+// update, or nil if the update is stale or a duplicate. Fills the exchange
+// did not report individually are synthesized from the filled quantity
+// delta, without a trade ID.
+func (s *OrderStore) apply(update *domain.Order) (*domain.Order, error) {
+	if update.ID == "" && update.ClientOrderID == "" {
+		return nil, errors.NewValidationError("order_id", nil, "order update has no order ID")
+	}
+
+	status := orNew(update.Status)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tracked := s.lookup(update.ID, update.ClientOrderID)
+	if tracked == nil {
+		if update.ID == "" {
+			return nil, errors.NewValidationError("order_id", update.ClientOrderID, "unknown client order ID")
+		}
+		tracked = &trackedOrder{
+			order:  &domain.Order{Status: status},
+			trades: make(map[string]struct{}),
+		}
+		s.orders[update.ID] = tracked
+		return s.merge(tracked, update, status), nil
+	}
+
+	current := tracked.order
+	curFilled := util.OrZero(current.FilledQuantity)
+	filled := curFilled
+	if update.FilledQuantity != nil {
+		filled = update.FilledQuantity
+	}
+
+	switch c := domain.Cmp(filled, curFilled); {
+	case c < 0:
+		return nil, nil // Stale: fills only ever grow
+	case c == 0 && status == current.Status:
+		// Duplicate; trade IDs it carries are recorded so they are not
+		// reported again
+		tracked.newFills(update.Fills)
+		return nil, nil
+	}
+
+	if status != current.Status && !current.Status.CanTransition(status) {
+		if statusRank(status) < statusRank(current.Status) || update.UpdatedAt.Before(current.UpdatedAt) {
+			return nil, nil // Stale: an earlier state delivered late
+		}
+		return nil, errors.NewValidationError("status", status,
+			fmt.Sprintf("illegal transition from %s for order %s", current.Status, current.ID))
+	}
+
+	return s.merge(tracked, update, status), nil
+}
+
+// merge overlays update onto the tracked order and returns the event copy.
+// Caller must hold mu.
+func (s *OrderStore) merge(tracked *trackedOrder, update *domain.Order, status domain.OrderStatus) *domain.Order {
+	current := tracked.order
+	next := *current
+	next.Fills = nil
+
+	next.Exchange = cmp.Or(update.Exchange, current.Exchange)
+	next.Symbol = cmp.Or(update.Symbol, current.Symbol)
+	next.ID = cmp.Or(update.ID, current.ID)
+	next.ClientOrderID = cmp.Or(update.ClientOrderID, current.ClientOrderID)
+	next.Side = cmp.Or(update.Side, current.Side)
+	next.Type = cmp.Or(update.Type, current.Type)
+	next.Status = status
+	next.Price = mergeDecimal(current.Price, update.Price)
+	next.Quantity = mergeDecimal(current.Quantity, update.Quantity)
+	next.FilledQuantity = mergeDecimal(current.FilledQuantity, update.FilledQuantity)
+	next.QuoteQuantity = mergeDecimal(current.QuoteQuantity, update.QuoteQuantity)
+	next.IsWorking = update.IsWorking
+	if next.CreatedAt.IsZero() {
+		next.CreatedAt = update.CreatedAt
+	}
+	if update.UpdatedAt.After(next.UpdatedAt) {
+		next.UpdatedAt = update.UpdatedAt
+	}
+
+	// New fills, by trade ID. Fills beyond the filled quantity delta were
+	// already reported without trade IDs and are only recorded.
+	filledDelta := domain.Sub(util.OrZero(next.FilledQuantity), util.OrZero(current.FilledQuantity))
+	fills := tracked.newFills(update.Fills)
+	covered := domain.Zero()
+	for _, fill := range fills {
+		covered = domain.Add(covered, util.OrZero(fill.Quantity))
+	}
+	for len(fills) > 0 && domain.Cmp(covered, filledDelta) > 0 {
+		covered = domain.Sub(covered, util.OrZero(fills[0].Quantity))
+		fills = fills[1:]
+	}
+
+	// Commission is cumulative when reported on the order, and otherwise
+	// summed from the fills
+	if update.Commission == nil || (domain.IsZero(update.Commission) && len(fills) > 0) {
+		for _, fill := range fills {
+			if next.CommissionAsset == "" || next.CommissionAsset == fill.CommissionAsset {
+				next.CommissionAsset = fill.CommissionAsset
+				next.Commission = domain.Add(util.OrZero(next.Commission), util.OrZero(fill.Commission))
+			}
+		}
+	} else {
+		next.Commission = mergeDecimal(current.Commission, update.Commission)
+		next.CommissionAsset = cmp.Or(update.CommissionAsset, current.CommissionAsset)
+	}
+	for _, fill := range fills {
+		next.TradeID = cmp.Or(fill.ID, next.TradeID)
+	}
+
+	// Fill quantity not covered by reported fills
+	if remaining := domain.Sub(filledDelta, covered); domain.IsPositive(remaining) {
+		quoteDelta := domain.Sub(util.OrZero(next.QuoteQuantity), util.OrZero(current.QuoteQuantity))
+		for _, fill := range fills {
+			quoteDelta = domain.Sub(quoteDelta, util.OrZero(fill.QuoteQuantity))
+		}
+		fill := domain.Trade{
+			Exchange:      next.Exchange,
+			Symbol:        next.Symbol,
+			OrderID:       next.ID,
+			Quantity:      remaining,
+			QuoteQuantity: quoteDelta,
+			Side:          next.Side,
+			Timestamp:     next.UpdatedAt,
+		}
+		if domain.IsPositive(quoteDelta) {
+			fill.Price = domain.Div(quoteDelta, remaining)
+		}
+		fills = append(fills, fill)
+	}
+
+	if current.ClientOrderID != "" && current.ClientOrderID != next.ClientOrderID {
+		delete(s.clientIDs, current.ClientOrderID)
+	}
+	if next.ClientOrderID != "" {
+		s.clientIDs[next.ClientOrderID] = next.ID
+	}
+
+	stored := next
+	stored.Fills = append(slices.Clip(current.Fills), fills...)
+	tracked.order = &stored
+
+	next.Fills = fills
+	return &next
+}
+
+// newFills returns the fills whose trade ID was not yet applied, and records
+// their IDs. Fills without a trade ID are always new.
+func (t *trackedOrder) newFills(fills []domain.Trade) []domain.Trade {
+	var fresh []domain.Trade
+	for _, fill := range fills {
+		if fill.ID != "" {
+			if _, seen := t.trades[fill.ID]; seen {
+				continue
+			}
+			t.trades[fill.ID] = struct{}{}
+		}
+		fresh = append(fresh, fill)
+	}
+	return fresh
+}
+
+// statusRank orders statuses by how far along the order lifecycle they are.
+func statusRank(status domain.OrderStatus) int {
+	switch {
+	case status == domain.OrderStatusNew:
+		return 0
+	case status == domain.OrderStatusPartiallyFilled:
+		return 1
+	case status == domain.OrderStatusCanceling:
+		return 2
+	case status.IsFinal():
+		return 3
+	default:
+		return 0
+	}
+}
+
+// orNew returns status, or NEW if it is empty (e.g., an ACK response).
+func orNew(status domain.OrderStatus) domain.OrderStatus {
+	if status == "" {
+		return domain.OrderStatusNew
+	}
+	return status
+}
+
+// copyOrder returns a copy of order with its own Fills slice.
+func copyOrder(order *domain.Order) *domain.Order {
+	c := *order
+	c.Fills = slices.Clone(order.Fills)
+	return &c
+}
+
+// mergeDecimal returns next unless it is missing, or zero where current is known.
+func mergeDecimal(current, next domain.Decimal) domain.Decimal {
+	if next == nil || (current != nil && domain.IsZero(next) && !domain.IsZero(current)) {
+		return current
+	}
+	return next
+}
@@ -0,0 +1,324 @@
+package connector
+
+import (
+	"context"
+	"maps"
+	"slices"
+	stdsync "sync"
+	"time"
+
+	"github.com/lilwiggy/ex-act/internal/util"
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+)
+
+const (
+	// reconcileTradesLimit is the page size for fill queries.
+	reconcileTradesLimit = 1000
+
+	// reconcileOverlap is subtracted from each checkpoint so fills stamped
+	// just before a run started are fetched again; duplicates are dropped.
+	reconcileOverlap = 5 * time.Second
+
+	// closedOrderRetention is how long closed orders stay in the order store.
+	closedOrderRetention = 24 * time.Hour
+)
+
+// DiscrepancyKind identifies what the reconciler could not explain.
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyMissingOrder: an order open locally could not be loaded from the exchange.
+	DiscrepancyMissingOrder DiscrepancyKind = "missing_order"
+	// DiscrepancyStateMismatch: the local state is ahead of, or contradicts, the exchange state.
+	DiscrepancyStateMismatch DiscrepancyKind = "state_mismatch"
+	// DiscrepancyOrphanFill: a fill belongs to an order that could not be loaded.
+	DiscrepancyOrphanFill DiscrepancyKind = "orphan_fill"
+)
+
+// Discrepancy describes order state the reconciler could not explain.
+type Discrepancy struct {
+	Kind    DiscrepancyKind
+	Symbol  string
+	OrderID string
+	Local   *domain.Order  // Local state, if known
+	Remote  *domain.Order  // Exchange state, if loaded
+	Fills   []domain.Trade // Fills of the order since the checkpoint
+	Err     error          // Why the exchange state could not be loaded or applied
+	At      time.Time
+}
+
+// Reconciler brings the order store back in line with the exchange after
+// user data stream frames were lost, e.g. across a reconnect.
+//
+// Each run loads the open orders and the fills since the last checkpoint,
+// then loads every order that is open locally but not on the exchange, or
+// that has fills not seen locally. The exchange state, with its fills, is
+// applied to the order store like any other update, so missed transitions
+// and fills are delivered to OnOrder. Anything that cannot be applied or
+// loaded is reported as a Discrepancy.
+//
+// The checkpoint advances only when a run completes.
+// All methods are safe for concurrent use.
+type Reconciler struct {
+	config        ReconcileConfig
+	trading       driver.Trading
+	orders        *OrderStore
+	emit          func(order *domain.Order)
+	onDiscrepancy func(discrepancy *Discrepancy)
+	onError       func(err error)
+
+	runMu      stdsync.Mutex // Serializes runs
+	mu         stdsync.Mutex
+	checkpoint time.Time
+	lastRun    time.Time
+	trigger    chan struct{}
+}
+
+// newReconciler creates a reconciler for the order store.
+// emit delivers updates the reconciler applied; onError receives failed
+// scheduled runs.
+func newReconciler(cfg ReconcileConfig, trading driver.Trading, orders *OrderStore, emit func(order *domain.Order), onDiscrepancy func(discrepancy *Discrepancy), onError func(err error)) *Reconciler {
+	return &Reconciler{
+		config:        cfg,
+		trading:       trading,
+		orders:        orders,
+		emit:          emit,
+		onDiscrepancy: onDiscrepancy,
+		onError:       onError,
+		checkpoint:    time.Now().Add(-cfg.Lookback),
+		trigger:       make(chan struct{}, 1),
+	}
+}
+
+// Checkpoint returns the time fills are fetched from on the next run.
+func (r *Reconciler) Checkpoint() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.checkpoint
+}
+
+// LastRun returns the start time of the last completed run, or zero.
+func (r *Reconciler) LastRun() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastRun
+}
+
+// Reconcile runs one reconciliation.
+// Discrepancies are reported to the handler; the error is only for requests
+// that failed, in which case the checkpoint is kept.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	started := time.Now()
+	since := r.Checkpoint()
+
+	remote, err := r.trading.GetOpenOrders(ctx, "")
+	if err != nil {
+		return err
+	}
+	local := r.orders.Open("")
+
+	remoteOpen := make(map[string]bool, len(remote))
+	symbols := make(map[string]struct{})
+	for _, order := range remote {
+		remoteOpen[order.ID] = true
+		symbols[order.Symbol] = struct{}{}
+	}
+	for _, order := range local {
+		symbols[order.Symbol] = struct{}{}
+	}
+
+	// Fills since the checkpoint, by order
+	fills := make(map[string][]domain.Trade)
+	for _, symbol := range slices.Sorted(maps.Keys(symbols)) {
+		trades, err := r.fetchTrades(ctx, symbol, since)
+		if err != nil {
+			return err
+		}
+		for _, trade := range trades {
+			fills[trade.OrderID] = append(fills[trade.OrderID], trade)
+		}
+	}
+
+	for _, order := range remote {
+		r.apply(order, fills[order.ID])
+		delete(fills, order.ID)
+	}
+
+	// Open locally but not on the exchange: closed while the stream was down
+	for _, order := range local {
+		if remoteOpen[order.ID] {
+			continue
+		}
+		current, err := r.trading.GetOrder(ctx, order.Symbol, order.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.report(&Discrepancy{
+				Kind:    DiscrepancyMissingOrder,
+				Symbol:  order.Symbol,
+				OrderID: order.ID,
+				Local:   order,
+				Fills:   fills[order.ID],
+				Err:     err,
+			})
+		} else {
+			r.apply(current, fills[order.ID])
+		}
+		delete(fills, order.ID)
+	}
+
+	// Fills of orders no longer open on either side
+	for _, orderID := range slices.Sorted(maps.Keys(fills)) {
+		trades := fills[orderID]
+		if r.orders.hasTrades(orderID, trades) {
+			continue
+		}
+		symbol := trades[0].Symbol
+		current, err := r.trading.GetOrder(ctx, symbol, orderID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			local, _ := r.orders.Get(orderID)
+			r.report(&Discrepancy{
+				Kind:    DiscrepancyOrphanFill,
+				Symbol:  symbol,
+				OrderID: orderID,
+				Local:   local,
+				Fills:   trades,
+				Err:     err,
+			})
+			continue
+		}
+		r.apply(current, trades)
+	}
+
+	r.orders.Prune(started.Add(-closedOrderRetention))
+
+	r.mu.Lock()
+	r.checkpoint = started.Add(-reconcileOverlap)
+	r.lastRun = started
+	r.mu.Unlock()
+	return nil
+}
+
+// apply merges the exchange state of an order, with its fills, into the
+// order store and emits the result. A state that is dropped while the local
+// state differs and is not newer is reported as a discrepancy.
+func (r *Reconciler) apply(remote *domain.Order, trades []domain.Trade) {
+	local, known := r.orders.Get(remote.ID)
+
+	update := *remote
+	update.Fills = slices.Clone(trades)
+	slices.SortStableFunc(update.Fills, func(a, b domain.Trade) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	applied, err := r.orders.apply(&update)
+	if err != nil {
+		r.report(&Discrepancy{
+			Kind:    DiscrepancyStateMismatch,
+			Symbol:  remote.Symbol,
+			OrderID: remote.ID,
+			Local:   local,
+			Remote:  remote,
+			Fills:   trades,
+			Err:     err,
+		})
+		return
+	}
+	if applied != nil {
+		r.emit(applied)
+		return
+	}
+
+	if !known || local.UpdatedAt.After(remote.UpdatedAt) {
+		return
+	}
+	if local.Status != orNew(remote.Status) || domain.Cmp(util.OrZero(local.FilledQuantity), util.OrZero(remote.FilledQuantity)) != 0 {
+		r.report(&Discrepancy{
+			Kind:    DiscrepancyStateMismatch,
+			Symbol:  remote.Symbol,
+			OrderID: remote.ID,
+			Local:   local,
+			Remote:  remote,
+			Fills:   trades,
+		})
+	}
+}
+
+// fetchTrades returns the fills for symbol since the given time, paging
+// forward by timestamp. Fills repeated at page boundaries are dropped.
+func (r *Reconciler) fetchTrades(ctx context.Context, symbol string, since time.Time) ([]domain.Trade, error) {
+	var trades []domain.Trade
+	seen := make(map[string]struct{})
+	for {
+		page, err := r.trading.GetMyTrades(ctx, symbol, since, reconcileTradesLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, trade := range page {
+			if _, ok := seen[trade.ID]; ok {
+				continue
+			}
+			seen[trade.ID] = struct{}{}
+			trades = append(trades, trade)
+			added++
+		}
+
+		// The driver steps through query windows up to now, so a short page
+		// is the last; a full page may end mid-millisecond, so also stop
+		// once nothing new arrives
+		if len(page) < reconcileTradesLimit || added == 0 {
+			return trades, nil
+		}
+		since = page[len(page)-1].Timestamp
+	}
+}
+
+// report stamps a discrepancy and forwards it to the handler.
+func (r *Reconciler) report(discrepancy *Discrepancy) {
+	discrepancy.At = time.Now()
+	if r.onDiscrepancy != nil {
+		r.onDiscrepancy(discrepancy)
+	}
+}
+
+// request schedules a run without waiting for it.
+// Requests made while a run is pending are coalesced.
+func (r *Reconciler) request() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// run reconciles on request and every Interval until ctx is done.
+func (r *Reconciler) run(ctx context.Context) {
+	var tick <-chan time.Time
+	if r.config.Interval > 0 {
+		ticker := time.NewTicker(r.config.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-r.trigger:
+		}
+
+		if err := r.Reconcile(ctx); err != nil && ctx.Err() == nil && r.onError != nil {
+			r.onError(err)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package connector
+
+import (
+	"slices"
+	stdsync "sync"
+	"sync/atomic"
+
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// OverflowPolicy selects what a channel subscription does with an update
+// when its consumer has fallen behind.
+type OverflowPolicy string
+
+const (
+	// OverflowBlock waits for the consumer. Nothing is dropped, but the
+	// stream's socket stalls, as with a slow handler.
+	OverflowBlock OverflowPolicy = "block"
+
+	// OverflowDropOldest discards the oldest buffered update to make room.
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+
+	// OverflowDropNewest discards the incoming update.
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+
+	// OverflowConflate keeps only the latest undelivered update per symbol,
+	// so the consumer always receives the current state. Symbols are
+	// delivered in the order they first became pending.
+	OverflowConflate OverflowPolicy = "conflate"
+)
+
+// SubscriptionOptions configures a channel subscription.
+type SubscriptionOptions struct {
+	Buffer   int            // Channel capacity (0 = default; unused with OverflowConflate)
+	Overflow OverflowPolicy // Overflow policy (empty = default)
+}
+
+// DefaultSubscriptionOptions returns default channel subscription options.
+func DefaultSubscriptionOptions() SubscriptionOptions {
+	return SubscriptionOptions{
+		Buffer:   256,
+		Overflow: OverflowDropOldest,
+	}
+}
+
+// Validate validates channel subscription options.
+func (o *SubscriptionOptions) Validate() error {
+	if o.Buffer < 0 {
+		return errors.NewValidationError("buffer", o.Buffer, "must not be negative")
+	}
+	switch o.Overflow {
+	case "", OverflowBlock, OverflowDropOldest, OverflowDropNewest, OverflowConflate:
+		return nil
+	default:
+		return errors.NewValidationError("overflow", o.Overflow, "unknown overflow policy")
+	}
+}
+
+// withDefaults returns the options with unset fields filled from
+// DefaultSubscriptionOptions.
+func (o SubscriptionOptions) withDefaults() SubscriptionOptions {
+	defaults := DefaultSubscriptionOptions()
+	if o.Buffer == 0 {
+		o.Buffer = defaults.Buffer
+	}
+	if o.Overflow == "" {
+		o.Overflow = defaults.Overflow
+	}
+	return o
+}
+
+// Subscription delivers stream updates on a channel, decoupling the consumer
+// from the socket read loop. Updates the consumer cannot keep up with are
+// handled by the subscription's OverflowPolicy.
+//
+// Updates are also delivered to the matching Handlers; both see the same
+// values, which must not be modified.
+// All methods are safe for concurrent use.
+type Subscription[T any] struct {
+	out    chan T
+	policy OverflowPolicy
+	match  func(v T) bool   // Whether an update belongs to the subscription
+	key    func(v T) string // Conflation key (symbol)
+
+	mu      stdsync.Mutex
+	closed  bool
+	pending map[string]T // OverflowConflate: latest undelivered update per key
+	order   []string     // OverflowConflate: pending keys, oldest first
+	wake    chan struct{}
+
+	dropped atomic.Uint64
+	waiting atomic.Int64 // OverflowConflate: len(order), readable without mu
+
+	done      chan struct{}
+	closeOnce stdsync.Once
+	pump      stdsync.WaitGroup
+	release   func()
+}
+
+// newSubscription creates a subscription delivering the updates match
+// accepts. opts must be valid.
+func newSubscription[T any](opts SubscriptionOptions, match func(v T) bool, key func(v T) string) *Subscription[T] {
+	opts = opts.withDefaults()
+	s := &Subscription[T]{
+		policy: opts.Overflow,
+		match:  match,
+		key:    key,
+		done:   make(chan struct{}),
+	}
+
+	if s.policy == OverflowConflate {
+		// Unbuffered, so a value is only taken off pending when the consumer
+		// is ready for it
+		s.out = make(chan T)
+		s.pending = make(map[string]T)
+		s.wake = make(chan struct{}, 1)
+		s.pump.Go(s.deliver)
+	} else {
+		s.out = make(chan T, opts.Buffer)
+	}
+	return s
+}
+
+// C returns the channel updates are delivered on.
+// It is closed by Close, and when the connector stops.
+func (s *Subscription[T]) C() <-chan T {
+	return s.out
+}
+
+// Dropped returns the number of updates discarded by the overflow policy.
+// With OverflowConflate, it counts updates replaced by a newer one before
+// delivery.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Len returns the number of updates waiting for the consumer.
+// It does not wait for a publisher blocked under OverflowBlock.
+func (s *Subscription[T]) Len() int {
+	return len(s.out) + int(s.waiting.Load())
+}
+
+// Close stops delivery, releases the streams of the subscription and
+// closes its channel. Undelivered updates are discarded.
+func (s *Subscription[T]) Close() {
+	s.closeOnce.Do(func() {
+		// Unblocks a publisher waiting under OverflowBlock
+		close(s.done)
+		if s.release != nil {
+			s.release()
+		}
+		s.pump.Wait()
+
+		s.mu.Lock()
+		s.closed = true
+		close(s.out)
+		s.mu.Unlock()
+	})
+}
+
+// publish delivers an update according to the overflow policy.
+func (s *Subscription[T]) publish(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	switch s.policy {
+	case OverflowConflate:
+		key := s.key(v)
+		if _, ok := s.pending[key]; ok {
+			s.dropped.Add(1)
+		} else {
+			s.order = append(s.order, key)
+			s.waiting.Add(1)
+		}
+		s.pending[key] = v
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+
+	case OverflowDropNewest:
+		select {
+		case s.out <- v:
+		default:
+			s.dropped.Add(1)
+		}
+
+	case OverflowDropOldest:
+		// Publishers hold s.mu, so only the consumer can take room meanwhile
+		for {
+			select {
+			case s.out <- v:
+				return
+			default:
+			}
+			select {
+			case <-s.out:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+
+	default:
+		select {
+		case s.out <- v:
+		case <-s.done:
+		}
+	}
+}
+
+// deliver sends pending conflated updates to the consumer until closed.
+func (s *Subscription[T]) deliver() {
+	for {
+		select {
+		case <-s.wake:
+		case <-s.done:
+			return
+		}
+
+		for {
+			s.mu.Lock()
+			if len(s.order) == 0 {
+				s.mu.Unlock()
+				break
+			}
+			key := s.order[0]
+			s.order = s.order[1:]
+			s.waiting.Add(-1)
+			v := s.pending[key]
+			delete(s.pending, key)
+			s.mu.Unlock()
+
+			select {
+			case s.out <- v:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// feed fans stream updates out to the channel subscriptions of one type.
+// The subscription list is replaced, never modified, so publishing does not
+// hold the lock while a subscription blocks.
+type feed[T any] struct {
+	mu   stdsync.Mutex
+	subs []*Subscription[T]
+}
+
+// add starts delivering updates to s.
+func (f *feed[T]) add(s *Subscription[T]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subs = append(slices.Clip(f.subs), s)
+}
+
+// remove stops delivering updates to s.
+func (f *feed[T]) remove(s *Subscription[T]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subs = slices.DeleteFunc(slices.Clone(f.subs), func(sub *Subscription[T]) bool {
+		return sub == s
+	})
+}
+
+// publish delivers an update to every subscription it matches.
+func (f *feed[T]) publish(v T) {
+	f.mu.Lock()
+	subs := f.subs
+	f.mu.Unlock()
+
+	for _, s := range subs {
+		if s.match(v) {
+			s.publish(v)
+		}
+	}
+}
+
+// closeAll closes every subscription.
+func (f *feed[T]) closeAll() {
+	f.mu.Lock()
+	subs := f.subs
+	f.mu.Unlock()
+
+	for _, s := range subs {
+		s.Close()
+	}
+}
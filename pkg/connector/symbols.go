@@ -0,0 +1,260 @@
+package connector
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	stdsync "sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+	"github.com/lilwiggy/ex-act/pkg/driver"
+)
+
+// SymbolDelisted is the status reported for a symbol no longer listed.
+const SymbolDelisted = "DELISTED"
+
+// symbolLoadTimeout bounds a shared full load, which outlives the
+// cancellation of the caller that started it.
+const symbolLoadTimeout = 30 * time.Second
+
+// SymbolStatusChange describes a symbol whose status changed between two
+// loads of exchange info.
+type SymbolStatusChange struct {
+	Symbol         string             // Normalized symbol
+	ExchangeSymbol string             // Exchange symbol
+	Previous       string             // Status before, or empty if newly listed
+	Current        string             // Status now, or SymbolDelisted
+	Info           *domain.SymbolInfo // Latest info; the last known info if delisted
+	At             time.Time
+}
+
+// symbolCache caches the info and trading rules of every listed symbol.
+//
+// The full listing is loaded on first use and again once it is older than
+// the TTL; concurrent loads are shared. If a reload fails, the stale listing
+// is served and the failure is passed to onError. Partial refreshes update
+// only the symbols they return.
+// Every load also updates the exchange's symbol registry, through the driver.
+//
+// The symbol map is replaced, never modified, so it can be read unlocked.
+type symbolCache struct {
+	load     func(ctx context.Context, query driver.ExchangeInfoQuery) (*domain.ExchangeInfo, error)
+	registry *domain.SymbolRegistry
+	ttl      time.Duration
+	onChange func(change *SymbolStatusChange)
+	onError  func(err error)
+
+	mu       stdsync.Mutex
+	symbols  map[string]*domain.SymbolInfo // Keyed by exchange symbol
+	loadedAt time.Time                     // Time of the last full load; zero until loaded
+	inflight *symbolLoad                   // Full load in progress
+}
+
+// symbolLoad is a full load shared by concurrent callers.
+type symbolLoad struct {
+	done chan struct{}
+	err  error
+}
+
+// newSymbolCache creates a cache that loads exchange info with load.
+// A ttl of zero or less never expires the listing. onChange receives the
+// status changes found by each load after the first; onError receives
+// failed loads that were not returned to a caller.
+func newSymbolCache(load func(ctx context.Context, query driver.ExchangeInfoQuery) (*domain.ExchangeInfo, error), registry *domain.SymbolRegistry, ttl time.Duration, onChange func(change *SymbolStatusChange), onError func(err error)) *symbolCache {
+	return &symbolCache{
+		load:     load,
+		registry: registry,
+		ttl:      ttl,
+		onChange: onChange,
+		onError:  onError,
+	}
+}
+
+// all returns the listing, loading it if missing or expired.
+func (c *symbolCache) all(ctx context.Context) (map[string]*domain.SymbolInfo, error) {
+	c.mu.Lock()
+	fresh := !c.loadedAt.IsZero() && (c.ttl <= 0 || time.Since(c.loadedAt) < c.ttl)
+	symbols := c.symbols
+	c.mu.Unlock()
+	if fresh {
+		return symbols, nil
+	}
+
+	err := c.refresh(ctx)
+
+	c.mu.Lock()
+	symbols, loaded := c.symbols, !c.loadedAt.IsZero()
+	c.mu.Unlock()
+	if err != nil {
+		if !loaded {
+			return nil, err
+		}
+		if ctx.Err() == nil {
+			log.Warn().Err(err).Msg("symbol reload failed, serving stale symbols")
+			c.reportError(err)
+		}
+	}
+	return symbols, nil
+}
+
+// get returns the info for symbol in normalized or exchange format.
+// Returns false if the symbol is not listed.
+func (c *symbolCache) get(ctx context.Context, symbol string) (*domain.SymbolInfo, bool, error) {
+	symbols, err := c.all(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	s, ok := symbols[c.registry.ExchangeSymbol(symbol)]
+	return s, ok, nil
+}
+
+// refresh reloads the full listing and reports status changes since the
+// previous load. A load already in progress is waited for instead.
+//
+// The load is shared, so it runs detached from ctx, bounded by
+// symbolLoadTimeout; each caller stops waiting when its own ctx is done.
+func (c *symbolCache) refresh(ctx context.Context) error {
+	c.mu.Lock()
+	call := c.inflight
+	if call == nil {
+		call = &symbolLoad{done: make(chan struct{})}
+		c.inflight = call
+		go c.share(context.WithoutCancel(ctx), call)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// share performs a shared full load and releases its waiters.
+func (c *symbolCache) share(ctx context.Context, call *symbolLoad) {
+	ctx, cancel := context.WithTimeout(ctx, symbolLoadTimeout)
+	defer cancel()
+
+	call.err = c.fetch(ctx, driver.ExchangeInfoQuery{})
+
+	c.mu.Lock()
+	c.inflight = nil
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// refreshPartial reloads the symbols selected by query and reports their
+// status changes. Symbols the query does not return are left as they are.
+func (c *symbolCache) refreshPartial(ctx context.Context, query driver.ExchangeInfoQuery) error {
+	if query.IsZero() {
+		return c.refresh(ctx)
+	}
+	return c.fetch(ctx, query)
+}
+
+// fetch loads exchange info for query, applies it and reports the changes.
+func (c *symbolCache) fetch(ctx context.Context, query driver.ExchangeInfoQuery) error {
+	info, err := c.load(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	changes := c.apply(info, query.IsZero())
+	if c.onChange != nil {
+		for _, change := range changes {
+			c.onChange(change)
+		}
+	}
+	return nil
+}
+
+// apply stores loaded symbols and returns the status changes against the
+// previous listing. A full load replaces the listing and also reports
+// symbols no longer listed. Nothing is reported before the first full load.
+func (c *symbolCache) apply(info *domain.ExchangeInfo, full bool) []*SymbolStatusChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous := c.symbols
+	var symbols map[string]*domain.SymbolInfo
+	if full || previous == nil {
+		symbols = make(map[string]*domain.SymbolInfo, len(info.Symbols))
+	} else {
+		symbols = maps.Clone(previous)
+	}
+	for i := range info.Symbols {
+		symbols[info.Symbols[i].ExchangeSymbol] = &info.Symbols[i]
+	}
+
+	report := !c.loadedAt.IsZero()
+	c.symbols = symbols
+	if full {
+		c.loadedAt = time.Now()
+	}
+	if !report {
+		return nil
+	}
+
+	now := time.Now()
+	var changes []*SymbolStatusChange
+	for i := range info.Symbols {
+		s := &info.Symbols[i]
+		old, listed := previous[s.ExchangeSymbol]
+		switch {
+		case !listed:
+			changes = append(changes, &SymbolStatusChange{Current: s.Status, Info: s})
+		case old.Status != s.Status:
+			changes = append(changes, &SymbolStatusChange{Previous: old.Status, Current: s.Status, Info: s})
+		}
+	}
+	if full {
+		for id, old := range previous {
+			if _, listed := symbols[id]; !listed {
+				changes = append(changes, &SymbolStatusChange{Previous: old.Status, Current: SymbolDelisted, Info: old})
+			}
+		}
+	}
+	slices.SortFunc(changes, func(a, b *SymbolStatusChange) int {
+		return cmp.Compare(a.Info.ExchangeSymbol, b.Info.ExchangeSymbol)
+	})
+	for _, change := range changes {
+		change.Symbol = change.Info.Symbol
+		change.ExchangeSymbol = change.Info.ExchangeSymbol
+		change.At = now
+	}
+	return changes
+}
+
+// run loads the listing, then reloads it every interval until ctx is done.
+// Failed loads are passed to onError and retried on the next tick.
+func (c *symbolCache) run(ctx context.Context, interval time.Duration) {
+	if err := c.refresh(ctx); err != nil && ctx.Err() == nil {
+		c.reportError(err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.refresh(ctx); err != nil && ctx.Err() == nil {
+				c.reportError(err)
+			}
+		}
+	}
+}
+
+// reportError passes a failed load to onError.
+func (c *symbolCache) reportError(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// SymbolFilters contains the trading rules of a symbol.
+// Filters the exchange does not report are nil and not enforced. Within a
+// filter, a nil or zero limit is disabled, as on Binance.
+// Documentation: https://binance-docs.github.io/apidocs/spot/en/#filters
+type SymbolFilters struct {
+	Price              *PriceFilter              `json:"price,omitempty"`                 // PRICE_FILTER
+	LotSize            *LotSizeFilter            `json:"lot_size,omitempty"`              // LOT_SIZE
+	MarketLotSize      *LotSizeFilter            `json:"market_lot_size,omitempty"`       // MARKET_LOT_SIZE
+	Notional           *NotionalFilter           `json:"notional,omitempty"`              // NOTIONAL or MIN_NOTIONAL
+	PercentPriceBySide *PercentPriceBySideFilter `json:"percent_price_by_side,omitempty"` // PERCENT_PRICE_BY_SIDE
+	MaxNumOrders       *MaxNumOrdersFilter       `json:"max_num_orders,omitempty"`        // MAX_NUM_ORDERS
+	IcebergParts       *IcebergPartsFilter       `json:"iceberg_parts,omitempty"`         // ICEBERG_PARTS
+}
+
+// PriceFilter limits the price and its increment.
+type PriceFilter struct {
+	MinPrice Decimal `json:"min_price,omitempty"`
+	MaxPrice Decimal `json:"max_price,omitempty"`
+	TickSize Decimal `json:"tick_size,omitempty"` // (price - MinPrice) must be a multiple
+}
+
+// LotSizeFilter limits the quantity and its increment.
+type LotSizeFilter struct {
+	MinQuantity Decimal `json:"min_quantity,omitempty"`
+	MaxQuantity Decimal `json:"max_quantity,omitempty"`
+	StepSize    Decimal `json:"step_size,omitempty"` // (quantity - MinQuantity) must be a multiple
+}
+
+// NotionalFilter limits the order value (price * quantity).
+// Market orders are checked against a reference price, and only for the
+// bounds that apply to market orders.
+type NotionalFilter struct {
+	MinNotional      Decimal `json:"min_notional,omitempty"`
+	MaxNotional      Decimal `json:"max_notional,omitempty"`
+	ApplyMinToMarket bool    `json:"apply_min_to_market"`
+	ApplyMaxToMarket bool    `json:"apply_max_to_market"`
+	AvgPriceMins     int     `json:"avg_price_mins"` // Minutes of the average price the exchange compares against
+}
+
+// PercentPriceBySideFilter limits the price relative to the average price,
+// with separate bounds for bids and asks.
+type PercentPriceBySideFilter struct {
+	BidMultiplierUp   Decimal `json:"bid_multiplier_up"`
+	BidMultiplierDown Decimal `json:"bid_multiplier_down"`
+	AskMultiplierUp   Decimal `json:"ask_multiplier_up"`
+	AskMultiplierDown Decimal `json:"ask_multiplier_down"`
+	AvgPriceMins      int     `json:"avg_price_mins"`
+}
+
+// MaxNumOrdersFilter limits the number of open orders on the symbol.
+type MaxNumOrdersFilter struct {
+	MaxNumOrders int `json:"max_num_orders"`
+}
+
+// IcebergPartsFilter limits the number of parts an iceberg order is split into.
+type IcebergPartsFilter struct {
+	Limit int `json:"limit"`
+}
+
+// FilterContext carries the market and account state some filters need.
+// Checks that need a missing value are skipped.
+type FilterContext struct {
+	// ReferencePrice approximates the exchange's average price; it is used
+	// by PERCENT_PRICE_BY_SIDE and for the notional of market orders
+	ReferencePrice Decimal
+
+	// OpenOrders is the number of orders open on the symbol, for MAX_NUM_ORDERS
+	OpenOrders int
+}
+
+// ValidateOrder checks an order request against the filters.
+// Every violation is reported as a *errors.ValidationError naming the
+// offending field (price, stop_price, quantity, notional, open_orders or
+// iceberg_quantity); several are joined into one error. Returns nil if the
+// request passes every filter that applies.
+func (f *SymbolFilters) ValidateOrder(req *OrderRequest, fc FilterContext) error {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	hasPrice := req.Type != OrderTypeMarket && isLimit(req.Price)
+	if f.Price != nil {
+		if hasPrice {
+			check(f.Price.validate("price", req.Price))
+		}
+		if isLimit(req.StopPrice) {
+			check(f.Price.validate("stop_price", req.StopPrice))
+		}
+	}
+
+	if isLimit(req.Quantity) {
+		if f.LotSize != nil {
+			check(f.LotSize.validate("quantity", req.Quantity))
+		}
+		if req.Type == OrderTypeMarket && f.MarketLotSize != nil {
+			check(f.MarketLotSize.validate("quantity", req.Quantity))
+		}
+	}
+
+	if f.Notional != nil {
+		check(f.Notional.validate(req, fc.ReferencePrice))
+	}
+
+	if f.PercentPriceBySide != nil && hasPrice && isLimit(fc.ReferencePrice) {
+		check(f.PercentPriceBySide.validate(req.Side, req.Price, fc.ReferencePrice))
+	}
+
+	if f.MaxNumOrders != nil && f.MaxNumOrders.MaxNumOrders > 0 && fc.OpenOrders >= f.MaxNumOrders.MaxNumOrders {
+		check(errors.NewValidationError("open_orders", fc.OpenOrders,
+			fmt.Sprintf("symbol already has the maximum of %d open orders", f.MaxNumOrders.MaxNumOrders)))
+	}
+
+	if f.IcebergParts != nil && f.IcebergParts.Limit > 0 && isLimit(req.IcebergQuantity) && isLimit(req.Quantity) {
+		parts := Div(req.Quantity, req.IcebergQuantity)
+		if Cmp(parts, NewDecimalFromInt(int64(f.IcebergParts.Limit))) > 0 {
+			check(errors.NewValidationError("iceberg_quantity", req.IcebergQuantity,
+				fmt.Sprintf("splits the order into more than %d parts", f.IcebergParts.Limit)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks a price against the filter.
+func (p *PriceFilter) validate(field string, price Decimal) error {
+	if isLimit(p.MinPrice) && Cmp(price, p.MinPrice) < 0 {
+		return errors.NewValidationError(field, price.String(), "below minimum price "+p.MinPrice.String())
+	}
+	if isLimit(p.MaxPrice) && Cmp(price, p.MaxPrice) > 0 {
+		return errors.NewValidationError(field, price.String(), "above maximum price "+p.MaxPrice.String())
+	}
+	if isLimit(p.TickSize) && !onStep(price, p.MinPrice, p.TickSize) {
+		return errors.NewValidationError(field, price.String(), "not a multiple of tick size "+p.TickSize.String())
+	}
+	return nil
+}
+
+// validate checks a quantity against the filter.
+func (l *LotSizeFilter) validate(field string, qty Decimal) error {
+	if isLimit(l.MinQuantity) && Cmp(qty, l.MinQuantity) < 0 {
+		return errors.NewValidationError(field, qty.String(), "below minimum quantity "+l.MinQuantity.String())
+	}
+	if isLimit(l.MaxQuantity) && Cmp(qty, l.MaxQuantity) > 0 {
+		return errors.NewValidationError(field, qty.String(), "above maximum quantity "+l.MaxQuantity.String())
+	}
+	if isLimit(l.StepSize) && !onStep(qty, l.MinQuantity, l.StepSize) {
+		return errors.NewValidationError(field, qty.String(), "not a multiple of step size "+l.StepSize.String())
+	}
+	return nil
+}
+
+// validate checks the order value against the filter.
+// Limit orders use their price; market orders use the quote quantity, or the
+// reference price times the quantity, and are skipped without either.
+func (n *NotionalFilter) validate(req *OrderRequest, refPrice Decimal) error {
+	market := req.Type == OrderTypeMarket
+	checkMin := isLimit(n.MinNotional) && (!market || n.ApplyMinToMarket)
+	checkMax := isLimit(n.MaxNotional) && (!market || n.ApplyMaxToMarket)
+	if !checkMin && !checkMax {
+		return nil
+	}
+
+	var notional Decimal
+	switch {
+	case market && isLimit(req.QuoteQuantity):
+		notional = req.QuoteQuantity
+	case market && isLimit(refPrice) && isLimit(req.Quantity):
+		notional = Mul(refPrice, req.Quantity)
+	case !market && isLimit(req.Price) && isLimit(req.Quantity):
+		notional = Mul(req.Price, req.Quantity)
+	default:
+		return nil
+	}
+
+	if checkMin && Cmp(notional, n.MinNotional) < 0 {
+		return errors.NewValidationError("notional", notional.String(), "below minimum notional "+n.MinNotional.String())
+	}
+	if checkMax && Cmp(notional, n.MaxNotional) > 0 {
+		return errors.NewValidationError("notional", notional.String(), "above maximum notional "+n.MaxNotional.String())
+	}
+	return nil
+}
+
+// validate checks a limit price against the side's bounds around refPrice.
+func (p *PercentPriceBySideFilter) validate(side OrderSide, price, refPrice Decimal) error {
+	up, down := p.BidMultiplierUp, p.BidMultiplierDown
+	if side == OrderSideSell {
+		up, down = p.AskMultiplierUp, p.AskMultiplierDown
+	}
+
+	if isLimit(up) {
+		if high := Mul(refPrice, up); Cmp(price, high) > 0 {
+			return errors.NewValidationError("price", price.String(), "above "+high.String()+" allowed relative to the average price")
+		}
+	}
+	if isLimit(down) {
+		if low := Mul(refPrice, down); Cmp(price, low) < 0 {
+			return errors.NewValidationError("price", price.String(), "below "+low.String()+" allowed relative to the average price")
+		}
+	}
+	return nil
+}
+
+// onStep reports whether (value - base) is a multiple of step.
+// A nil base counts as zero.
+func onStep(value, base, step Decimal) bool {
+	return IsZero(stepRemainder(value, base, step))
+}
+
+// isLimit reports whether d is set: non-nil and positive.
+func isLimit(d Decimal) bool {
+	return d != nil && IsPositive(d)
+}
@@ -0,0 +1,197 @@
+package domain
+
+import "github.com/cockroachdb/apd/v3"
+
+// Quantizer snaps order prices and quantities to a symbol's tick and step
+// sizes, so raw strategy outputs can be sent without -1013 rejections.
+//
+// Limit prices are rounded in the passive direction: down for buys, up for
+// sells, so the order never pays more or receives less than requested.
+// Quantities are always rounded down. With BumpToMinNotional, quantities
+// below the minimum quantity or notional are raised to the smallest step
+// that meets both instead.
+//
+// Snapping does not clamp to the price and quantity bounds; requests outside
+// them still fail SymbolFilters.ValidateOrder.
+type Quantizer struct {
+	info *SymbolInfo
+
+	// BumpToMinNotional raises quantities below the minimums
+	BumpToMinNotional bool
+}
+
+// NewQuantizer creates a quantizer for a symbol.
+func NewQuantizer(info *SymbolInfo) *Quantizer {
+	return &Quantizer{info: info}
+}
+
+// Price snaps a limit price to the tick size, down for buys and up for sells.
+func (q *Quantizer) Price(side OrderSide, price Decimal) Decimal {
+	f := q.info.Filters.Price
+	if f == nil || !isLimit(f.TickSize) || price == nil {
+		return price
+	}
+	if side == OrderSideSell {
+		return ceilStep(price, f.MinPrice, f.TickSize)
+	}
+	return floorStep(price, f.MinPrice, f.TickSize)
+}
+
+// StopPrice snaps a stop price to the nearest tick.
+func (q *Quantizer) StopPrice(price Decimal) Decimal {
+	f := q.info.Filters.Price
+	if f == nil || !isLimit(f.TickSize) || price == nil {
+		return price
+	}
+	return nearestStep(price, f.MinPrice, f.TickSize)
+}
+
+// Quantity floors a quantity to the lot step of the order type.
+// Market orders use MARKET_LOT_SIZE where it sets a step, else LOT_SIZE.
+func (q *Quantizer) Quantity(orderType OrderType, qty Decimal) Decimal {
+	f := q.lotSize(orderType)
+	if f == nil || qty == nil {
+		return qty
+	}
+	return floorStep(qty, f.MinQuantity, f.StepSize)
+}
+
+// MinQuantity returns the smallest quantity on the lot step that meets the
+// minimum quantity and, at price, the minimum notional.
+// Returns nil if neither minimum applies.
+func (q *Quantizer) MinQuantity(orderType OrderType, price Decimal) Decimal {
+	var minQty Decimal
+	f := q.lotSize(orderType)
+	if f != nil && isLimit(f.MinQuantity) {
+		minQty = f.MinQuantity
+	}
+
+	n := q.info.Filters.Notional
+	market := orderType == OrderTypeMarket
+	if n != nil && isLimit(n.MinNotional) && (!market || n.ApplyMinToMarket) && isLimit(price) {
+		qty := Div(n.MinNotional, price)
+		if minQty == nil || Cmp(qty, minQty) > 0 {
+			minQty = qty
+		}
+	}
+
+	if minQty == nil || f == nil {
+		return minQty
+	}
+	return ceilStep(minQty, f.MinQuantity, f.StepSize)
+}
+
+// Limit builds a limit order request with the price and quantity snapped.
+func (q *Quantizer) Limit(side OrderSide, price, qty Decimal) *OrderRequest {
+	req := &OrderRequest{
+		Exchange: q.info.Exchange,
+		Symbol:   q.info.Symbol,
+		Side:     side,
+		Type:     OrderTypeLimit,
+		Price:    price,
+		Quantity: qty,
+	}
+	q.Quantize(req, nil)
+	return req
+}
+
+// Market builds a market order request with the quantity snapped.
+// refPrice is used to raise the quantity to the minimum notional, and may
+// be nil.
+func (q *Quantizer) Market(side OrderSide, qty, refPrice Decimal) *OrderRequest {
+	req := &OrderRequest{
+		Exchange: q.info.Exchange,
+		Symbol:   q.info.Symbol,
+		Side:     side,
+		Type:     OrderTypeMarket,
+		Quantity: qty,
+	}
+	q.Quantize(req, refPrice)
+	return req
+}
+
+// Quantize snaps the prices and quantities of req in place.
+// The minimum notional of market orders is computed at refPrice, and
+// skipped if it is nil. Quote quantities are left as they are.
+func (q *Quantizer) Quantize(req *OrderRequest, refPrice Decimal) {
+	if req.Type != OrderTypeMarket {
+		req.Price = q.Price(req.Side, req.Price)
+		refPrice = req.Price
+	}
+	req.StopPrice = q.StopPrice(req.StopPrice)
+
+	if req.Quantity == nil || IsZero(req.Quantity) {
+		return
+	}
+	req.Quantity = q.Quantity(req.Type, req.Quantity)
+	if q.BumpToMinNotional {
+		if minQty := q.MinQuantity(req.Type, refPrice); minQty != nil && Cmp(req.Quantity, minQty) < 0 {
+			req.Quantity = minQty
+		}
+	}
+	if req.IcebergQuantity != nil {
+		req.IcebergQuantity = q.Quantity(OrderTypeLimit, req.IcebergQuantity)
+	}
+}
+
+// lotSize returns the lot filter with a step for the order type, or nil.
+func (q *Quantizer) lotSize(orderType OrderType) *LotSizeFilter {
+	filters := q.info.Filters
+	if orderType == OrderTypeMarket && filters.MarketLotSize != nil && isLimit(filters.MarketLotSize.StepSize) {
+		return filters.MarketLotSize
+	}
+	if filters.LotSize != nil && isLimit(filters.LotSize.StepSize) {
+		return filters.LotSize
+	}
+	return nil
+}
+
+// floorStep rounds value down to base plus a multiple of step.
+// A nil base counts as zero.
+func floorStep(value, base, step Decimal) Decimal {
+	return toStepPrecision(Sub(value, stepRemainder(value, base, step)), step)
+}
+
+// ceilStep rounds value up to base plus a multiple of step.
+func ceilStep(value, base, step Decimal) Decimal {
+	rem := stepRemainder(value, base, step)
+	if IsZero(rem) {
+		return toStepPrecision(value, step)
+	}
+	return toStepPrecision(Add(Sub(value, rem), step), step)
+}
+
+// nearestStep rounds value to the nearest base plus a multiple of step,
+// with halves rounded up.
+func nearestStep(value, base, step Decimal) Decimal {
+	rem := stepRemainder(value, base, step)
+	if Cmp(Add(rem, rem), step) >= 0 {
+		return toStepPrecision(Add(Sub(value, rem), step), step)
+	}
+	return toStepPrecision(Sub(value, rem), step)
+}
+
+// toStepPrecision sets the decimal places of a value on the step to those
+// of the step, without trailing zeros, since exchanges reject values with
+// more places than the step (e.g., Binance -1111).
+func toStepPrecision(value, step Decimal) Decimal {
+	var reduced apd.Decimal
+	reduced.Reduce(step)
+	if reduced.Exponent >= 0 {
+		return Round(value, 0)
+	}
+	return Round(value, uint32(-reduced.Exponent))
+}
+
+// stepRemainder returns (value - base) mod step, in [0, step).
+func stepRemainder(value, base, step Decimal) Decimal {
+	offset := value
+	if base != nil {
+		offset = Sub(value, base)
+	}
+	rem := Mod(offset, step)
+	if IsNegative(rem) {
+		rem = Add(rem, step)
+	}
+	return rem
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"strings"
+	"sync"
+)
+
+// SymbolRegistry maps exchange symbols to normalized symbols using the base
+// and quote assets the exchange lists, instead of guessing the split from
+// known quote currencies.
+//
+// Symbols are added from exchange info and never removed, so orders and
+// fills of delisted symbols still convert. Symbols not registered fall back
+// to NormalizeSymbol and ExchangeSymbol.
+// All methods are safe for concurrent use.
+type SymbolRegistry struct {
+	mu         sync.RWMutex
+	normalized map[string]string // Exchange symbol to normalized symbol
+	exchange   map[string]string // Normalized symbol to exchange symbol
+}
+
+// registries holds one registry per exchange.
+var registries sync.Map
+
+// NewSymbolRegistry creates an empty registry.
+func NewSymbolRegistry() *SymbolRegistry {
+	return &SymbolRegistry{
+		normalized: make(map[string]string),
+		exchange:   make(map[string]string),
+	}
+}
+
+// Symbols returns the shared registry for an exchange.
+// Drivers register listed symbols whenever they load exchange info.
+func Symbols(exchange string) *SymbolRegistry {
+	if r, ok := registries.Load(exchange); ok {
+		return r.(*SymbolRegistry)
+	}
+	r, _ := registries.LoadOrStore(exchange, NewSymbolRegistry())
+	return r.(*SymbolRegistry)
+}
+
+// Register adds or updates symbols.
+// Symbols without a base or quote asset are skipped.
+func (r *SymbolRegistry) Register(symbols ...SymbolInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range symbols {
+		s := &symbols[i]
+		if s.BaseAsset == "" || s.QuoteAsset == "" || s.ExchangeSymbol == "" {
+			continue
+		}
+		exchangeSymbol := strings.ToUpper(s.ExchangeSymbol)
+		normalized := FormatSymbol(s.BaseAsset, s.QuoteAsset)
+		r.normalized[exchangeSymbol] = normalized
+		r.exchange[normalized] = exchangeSymbol
+	}
+}
+
+// Normalize converts a symbol in exchange or normalized format to
+// normalized format (e.g., "BTCUSDC" -> "BTC/USDC").
+func (r *SymbolRegistry) Normalize(symbol string) string {
+	if strings.Contains(symbol, "/") {
+		return strings.ToUpper(symbol)
+	}
+
+	r.mu.RLock()
+	normalized, ok := r.normalized[strings.ToUpper(symbol)]
+	r.mu.RUnlock()
+	if ok {
+		return normalized
+	}
+	return NormalizeSymbol(symbol)
+}
+
+// ExchangeSymbol converts a symbol in normalized or exchange format to
+// exchange format (e.g., "BTC/USDC" -> "BTCUSDC").
+func (r *SymbolRegistry) ExchangeSymbol(symbol string) string {
+	if !strings.Contains(symbol, "/") {
+		return strings.ToUpper(symbol)
+	}
+
+	r.mu.RLock()
+	exchangeSymbol, ok := r.exchange[strings.ToUpper(symbol)]
+	r.mu.RUnlock()
+	if ok {
+		return exchangeSymbol
+	}
+	return ExchangeSymbol(symbol)
+}
+
+// Split returns the base and quote assets of a symbol in either format.
+// Returns false if the symbol is neither registered nor normalized.
+func (r *SymbolRegistry) Split(symbol string) (base, quote string, ok bool) {
+	base, quote, ok = strings.Cut(r.Normalize(symbol), "/")
+	return base, quote, ok
+}
+
+// Len returns the number of registered symbols.
+func (r *SymbolRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.normalized)
+}
@@ -3,7 +3,9 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 // SymbolInfo contains metadata about a trading symbol.
@@ -52,6 +54,68 @@ type SymbolInfo struct {
 
 	// MinNotional is the minimum order value (price * quantity)
 	MinNotional Decimal `json:"min_notional,omitempty"`
+
+	// OrderTypes lists the accepted order types in exchange format
+	// (e.g., "LIMIT", "LIMIT_MAKER", "MARKET")
+	OrderTypes []string `json:"order_types,omitempty"`
+
+	// Permissions lists the account permissions that may trade the symbol
+	// (e.g., "SPOT", "MARGIN")
+	Permissions []string `json:"permissions,omitempty"`
+
+	// IcebergAllowed indicates if iceberg orders are accepted
+	IcebergAllowed bool `json:"iceberg_allowed"`
+
+	// OCOAllowed indicates if OCO orders are accepted
+	OCOAllowed bool `json:"oco_allowed"`
+
+	// SpotTradingAllowed indicates if the symbol trades on spot accounts
+	SpotTradingAllowed bool `json:"spot_trading_allowed"`
+
+	// MarginTradingAllowed indicates if the symbol trades on margin accounts
+	MarginTradingAllowed bool `json:"margin_trading_allowed"`
+
+	// Filters contains the trading rules orders are validated against
+	Filters SymbolFilters `json:"filters"`
+}
+
+// IsTrading returns true if the symbol accepts orders.
+func (s *SymbolInfo) IsTrading() bool {
+	return strings.EqualFold(s.Status, "TRADING")
+}
+
+// SupportsOrderType returns true if the symbol accepts the order type.
+func (s *SymbolInfo) SupportsOrderType(orderType OrderType) bool {
+	return slices.Contains(s.OrderTypes, string(orderType))
+}
+
+// HasPermission returns true if the symbol can be traded with the permission.
+func (s *SymbolInfo) HasPermission(permission string) bool {
+	return slices.ContainsFunc(s.Permissions, func(p string) bool {
+		return strings.EqualFold(p, permission)
+	})
+}
+
+// ExchangeInfo contains exchange trading rules and symbol metadata.
+type ExchangeInfo struct {
+	// Exchange is the name of the exchange
+	Exchange string `json:"exchange"`
+
+	// ServerTime is the exchange time when the info was produced
+	ServerTime time.Time `json:"server_time"`
+
+	// Symbols contains metadata for every listed symbol
+	Symbols []SymbolInfo `json:"symbols"`
+}
+
+// Symbol returns the info for a symbol in normalized or exchange format.
+func (e *ExchangeInfo) Symbol(symbol string) (*SymbolInfo, bool) {
+	for i := range e.Symbols {
+		if e.Symbols[i].ExchangeSymbol == ExchangeSymbol(symbol) {
+			return &e.Symbols[i], true
+		}
+	}
+	return nil, false
 }
 
 // NormalizeSymbol converts an exchange-specific symbol to normalized format.
@@ -60,6 +124,8 @@ type SymbolInfo struct {
 //   - Bybit: "BTCUSDT" -> "BTC/USDT"
 //
 // The function attempts to find common quote currencies to split the symbol.
+// It guesses wrong for quotes it does not know (e.g., "ETHFDUSD" becomes
+// "ETHFD/USD"); SymbolRegistry uses the listed assets instead.
 func NormalizeSymbol(exchangeSymbol string) string {
 	// If already normalized (contains "/"), return as-is
 	if strings.Contains(exchangeSymbol, "/") {
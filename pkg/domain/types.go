@@ -82,11 +82,13 @@ func (s OrderStatus) CanTransition(newStatus OrderStatus) bool {
 			OrderStatusFilled,
 			OrderStatusCanceling,
 			OrderStatusCanceled,
+			OrderStatusExpired, // IOC or market order filled in part, or EXPIRED_IN_MATCH
 		},
 		OrderStatusCanceling: {
 			OrderStatusPartiallyFilled,
 			OrderStatusFilled,
 			OrderStatusCanceled,
+			OrderStatusExpired,
 		},
 		// Final states cannot transition
 		OrderStatusFilled:   {},
@@ -161,6 +163,9 @@ type Order struct {
 
 	// IsWorking indicates if the order is on the order book
 	IsWorking bool `json:"is_working"`
+
+	// Fills contains the executions reported with the order (if available)
+	Fills []Trade `json:"fills,omitempty"`
 }
 
 // IsFilled returns true if the order is fully filled.
@@ -368,6 +373,26 @@ type Ticker struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Top returns a copy of the book limited to the best depth levels per side.
+// A depth of zero or less copies every level. Level decimals are shared.
+func (ob *OrderBook) Top(depth int) *OrderBook {
+	top := *ob
+	top.Bids = topLevels(ob.Bids, depth)
+	top.Asks = topLevels(ob.Asks, depth)
+	return &top
+}
+
+// topLevels copies up to depth levels; zero or less copies all.
+func topLevels(levels []OrderBookLevel, depth int) []OrderBookLevel {
+	n := len(levels)
+	if depth > 0 {
+		n = min(n, depth)
+	}
+	out := make([]OrderBookLevel, n)
+	copy(out, levels[:n])
+	return out
+}
+
 // Spread returns the bid-ask spread.
 func (t *Ticker) Spread() Decimal {
 	return Sub(t.AskPrice, t.BidPrice)
@@ -0,0 +1,242 @@
+// Package driver defines the exchange driver contract used by the connector.
+// Each venue (Binance, Bybit, test fakes) implements Driver and registers a
+// Factory under its exchange name. The connector only ever sees domain types.
+package driver
+
+import (
+	"context"
+	"time"
+
+	"github.com/lilwiggy/ex-act/pkg/domain"
+)
+
+// Driver is the full exchange driver contract.
+// Implementations must be safe for concurrent use.
+type Driver interface {
+	// Name returns the exchange name (e.g., "binance", "bybit").
+	Name() string
+
+	// Connect starts the streaming connections.
+	// It returns once the first connection attempt has completed.
+	Connect(ctx context.Context) error
+
+	// Close permanently releases all REST and streaming resources.
+	Close() error
+
+	// IsConnected returns true if the market data stream is connected.
+	IsConnected() bool
+
+	// Backoff reports whether traffic is held back after an IP-level rate
+	// limit response or IP ban.
+	Backoff() BackoffStatus
+
+	MarketData
+	Trading
+	Account
+	Streams
+}
+
+// MarketData provides public REST market data.
+type MarketData interface {
+	// Ping tests REST connectivity.
+	Ping(ctx context.Context) error
+
+	// GetServerTime returns the exchange server time in milliseconds.
+	GetServerTime(ctx context.Context) (int64, error)
+
+	// GetExchangeInfo returns exchange trading rules and symbols.
+	// A zero query returns every listed symbol.
+	GetExchangeInfo(ctx context.Context, query ExchangeInfoQuery) (*domain.ExchangeInfo, error)
+
+	// GetKlines returns the klines opened between start and end, oldest first.
+	// interval uses the normalized form (e.g., "1m", "1h", "1d").
+	// Ranges longer than one page are fetched in several requests.
+	GetKlines(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error)
+}
+
+// Trading provides order entry and order queries.
+type Trading interface {
+	// PlaceOrder submits a new order.
+	PlaceOrder(ctx context.Context, req *domain.OrderRequest) (*domain.Order, error)
+
+	// CancelOrder cancels a single order.
+	CancelOrder(ctx context.Context, req *domain.CancelRequest) (*domain.Order, error)
+
+	// CancelAllOrders cancels all open orders for a symbol.
+	CancelAllOrders(ctx context.Context, symbol string) ([]*domain.Order, error)
+
+	// GetOrder returns a single order by exchange order ID.
+	GetOrder(ctx context.Context, symbol, orderID string) (*domain.Order, error)
+
+	// GetOpenOrders returns open orders; an empty symbol means all symbols.
+	GetOpenOrders(ctx context.Context, symbol string) ([]*domain.Order, error)
+
+	// GetAllOrders returns order history for a symbol.
+	// Zero times and limit use the exchange defaults.
+	GetAllOrders(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.Order, error)
+
+	// GetMyTrades returns the account's fills for a symbol executed at or
+	// after since, oldest first. A zero since and limit use the exchange defaults.
+	// With a since, query windows the exchange imposes are stepped through up
+	// to now, so fewer than limit fills means there are no later fills.
+	GetMyTrades(ctx context.Context, symbol string, since time.Time, limit int) ([]domain.Trade, error)
+}
+
+// Account provides account state.
+type Account interface {
+	// GetBalances returns all non-empty asset balances.
+	GetBalances(ctx context.Context) ([]domain.Balance, error)
+}
+
+// Streams provides streaming subscriptions.
+// Data is delivered through the callbacks set with SetCallbacks.
+type Streams interface {
+	// SetCallbacks sets the stream callbacks. Must be called before Connect.
+	SetCallbacks(cb Callbacks)
+
+	// Subscribe adds a stream subscription.
+	Subscribe(sub Subscription) error
+
+	// Unsubscribe removes a stream subscription.
+	Unsubscribe(sub Subscription) error
+}
+
+// Channel identifies a market data stream type.
+type Channel string
+
+const (
+	ChannelTicker    Channel = "ticker"
+	ChannelOrderBook Channel = "orderbook"
+	ChannelTrade     Channel = "trade"
+	ChannelKline     Channel = "kline"
+)
+
+// Subscription identifies a single stream.
+type Subscription struct {
+	Channel  Channel // Stream type
+	Symbol   string  // Symbol in exchange or normalized format
+	Interval string  // Kline interval (ChannelKline only, e.g., "1m")
+}
+
+// ExchangeInfoQuery narrows an exchange info request to some symbols.
+// Filters the exchange cannot apply are applied by the driver.
+type ExchangeInfoQuery struct {
+	Symbols     []string // Only these symbols, in exchange or normalized format
+	Permissions []string // Only symbols with any of these permissions (e.g., "SPOT", "MARGIN")
+}
+
+// IsZero reports whether the query returns every listed symbol.
+func (q ExchangeInfoQuery) IsZero() bool {
+	return len(q.Symbols) == 0 && len(q.Permissions) == 0
+}
+
+// Callbacks contains stream callbacks.
+// Callbacks are invoked from the driver's read loop and must not block.
+type Callbacks struct {
+	OnTicker     func(ticker *domain.Ticker)
+	OnOrderBook  func(orderBook *domain.OrderBook)
+	OnTrade      func(trade *domain.Trade)
+	OnKline      func(kline *domain.Kline)
+	OnOrder      func(order *domain.Order)
+	OnBalance    func(balance *domain.Balance) // Full balance state for an asset
+	OnConnect    func()
+	OnDisconnect func(err error)
+	OnError      func(err error) // Non-fatal background errors (e.g., stream maintenance)
+
+	// OnBookResync reports that a locally maintained order book was invalidated
+	// (sequence gap or reconnect) and is being rebuilt from a fresh snapshot.
+	// No books are published for the symbol until the resync completes.
+	OnBookResync func(symbol string, reason error)
+
+	// OnBalanceDelta reports a change to an asset's free balance that is not
+	// accompanied by a full balance (e.g., deposits and withdrawals).
+	OnBalanceDelta func(asset string, delta domain.Decimal, at time.Time)
+
+	// OnUserDataConnect and OnUserDataDisconnect report the state of the
+	// authenticated stream carrying order and balance updates. While it is
+	// down, order updates are missed.
+	OnUserDataConnect    func()
+	OnUserDataDisconnect func(err error)
+}
+
+// CancelCountdown is implemented by drivers whose exchange can cancel all open
+// orders by itself when the client goes away (e.g., Bybit Disconnect Cancel
+// Protection). It complements a client-side dead-man's switch, which cannot
+// fire if the process itself is frozen.
+type CancelCountdown interface {
+	// ArmCancelCountdown makes the exchange cancel all open orders once the
+	// authenticated stream has been disconnected for window.
+	// Must be called after the authenticated stream is connected.
+	ArmCancelCountdown(ctx context.Context, window time.Duration) error
+}
+
+// BackoffStatus describes a driver's rate limit backoff.
+// While Active, REST requests and new WebSocket connections are held back:
+// they wait out a rate limit backoff and are rejected during an IP ban.
+type BackoffStatus struct {
+	Active bool      // Traffic is held back
+	Banned bool      // Caused by an IP ban rather than a rate limit
+	Until  time.Time // When traffic resumes
+	Reason error     // Error that started the backoff
+}
+
+// Clock is a synchronised exchange clock.
+// Drivers stamp signed requests with it and resync it when the exchange
+// rejects a timestamp. *sync.ClockSync implements Clock.
+type Clock interface {
+	// UnixMilli returns the current exchange time in milliseconds.
+	UnixMilli() int64
+
+	// Sync measures the offset to the exchange clock again.
+	Sync() error
+}
+
+// ClockStats describes the quality of a clock offset estimate.
+type ClockStats struct {
+	Offset     time.Duration `json:"offset"`      // Filtered offset (server - local), including drift
+	DriftPPM   float64       `json:"drift_ppm"`   // Offset change in parts per million of elapsed time
+	RTT        time.Duration `json:"rtt"`         // Lowest round-trip time of the last sync
+	Jitter     time.Duration `json:"jitter"`      // Standard deviation of the accepted samples
+	ErrorBound time.Duration `json:"error_bound"` // Maximum error of Offset: RTT/2 + Jitter
+	Confidence float64       `json:"confidence"`  // 0 (unusable) to 1 (error bound negligible next to the max offset)
+	Samples    int           `json:"samples"`     // Samples taken in the last sync
+	Accepted   int           `json:"accepted"`    // Samples used for the estimate
+	LastSync   time.Time     `json:"last_sync"`   // Time of the last successful sync
+}
+
+// API key types. Exchanges sign requests with a shared secret (HMAC) or with
+// an asymmetric private key (RSA, Ed25519) whose public key is registered
+// with the exchange.
+const (
+	KeyTypeHMAC    = "HMAC"
+	KeyTypeRSA     = "RSA"
+	KeyTypeEd25519 = "ED25519"
+)
+
+// Order transports. The WebSocket transport keeps a persistent authenticated
+// connection for order entry, avoiding a TLS handshake and HTTP round trip per
+// order; queries and bulk operations still use REST.
+const (
+	OrderTransportREST      = "rest"
+	OrderTransportWebSocket = "websocket"
+)
+
+// Config contains the settings passed to a driver Factory.
+type Config struct {
+	Name       string        // Exchange name
+	APIKey     string        // API key (empty for public-only access)
+	APISecret  string        // API secret, or PKCS#8 PEM private key for RSA and Ed25519 keys
+	KeyType    string        // API key type: KeyTypeHMAC (default), KeyTypeRSA or KeyTypeEd25519
+	Testnet    bool          // Use testnet endpoints
+	Timeout    time.Duration // REST request timeout
+	MaxWeight  int           // REST weight budget per minute (weight-based venues)
+	RecvWindow int64         // Signed request validity window in milliseconds
+	Clock      Clock         // Exchange clock for signed request timestamps (nil = driver-owned)
+
+	OrderTransport string // OrderTransportREST (default) or OrderTransportWebSocket
+
+	PingInterval      time.Duration // WebSocket heartbeat interval
+	ReconnectDelay    time.Duration // Initial reconnect delay
+	MaxReconnectWait  time.Duration // Maximum reconnect delay
+	MaxStreamsPerConn int           // Streams per WebSocket connection before sharding (0 = venue limit)
+}
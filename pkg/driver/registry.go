@@ -0,0 +1,65 @@
+package driver
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lilwiggy/ex-act/pkg/errors"
+)
+
+// Factory creates a Driver from configuration.
+type Factory func(cfg Config) (Driver, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a driver factory available under an exchange name.
+// Registering the same name again replaces the previous factory, which lets
+// tests swap a venue for a fake.
+func Register(name string, factory Factory) {
+	if name == "" || factory == nil {
+		panic("driver: Register requires a name and a factory")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[name] = factory
+}
+
+// IsRegistered returns true if a factory is registered for name.
+func IsRegistered(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	_, ok := registry[name]
+	return ok
+}
+
+// Registered returns the sorted list of registered exchange names.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates a driver using the factory registered for cfg.Name.
+func New(cfg Config) (Driver, error) {
+	registryMu.RLock()
+	factory, ok := registry[cfg.Name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, errors.NewNotFoundError("driver", cfg.Name)
+	}
+
+	return factory(cfg)
+}
@@ -161,3 +161,6 @@ var As = errors.As
 
 // New creates a new error with the given message.
 var New = errors.New
+
+// Join is an alias for errors.Join for convenience.
+var Join = errors.Join
@@ -0,0 +1,43 @@
+package errors
+
+import "fmt"
+
+// SequenceGapError represents a break in a market data stream's update sequence.
+// A local order book that hits a gap is invalid until it is resynced from a snapshot.
+type SequenceGapError struct {
+	// Exchange is the name of the exchange
+	Exchange string `json:"exchange"`
+
+	// Symbol is the trading pair whose stream broke
+	Symbol string `json:"symbol"`
+
+	// Expected is the next update ID the book required
+	Expected int64 `json:"expected"`
+
+	// Received is the first update ID that arrived (0 if none, e.g. on reconnect)
+	Received int64 `json:"received,omitempty"`
+
+	// Message is a human-readable error message
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *SequenceGapError) Error() string {
+	if e.Received > 0 {
+		return fmt.Sprintf("[%s] sequence gap on %s (expected %d, received %d): %s",
+			e.Exchange, e.Symbol, e.Expected, e.Received, e.Message)
+	}
+	return fmt.Sprintf("[%s] sequence gap on %s (expected %d): %s",
+		e.Exchange, e.Symbol, e.Expected, e.Message)
+}
+
+// NewSequenceGapError creates a new SequenceGapError.
+func NewSequenceGapError(exchange, symbol string, expected, received int64, message string) *SequenceGapError {
+	return &SequenceGapError{
+		Exchange: exchange,
+		Symbol:   symbol,
+		Expected: expected,
+		Received: received,
+		Message:  message,
+	}
+}
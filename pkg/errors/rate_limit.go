@@ -65,6 +65,9 @@ type IPBanError struct {
 	// RetryAfter is the duration until the ban is lifted (if known)
 	RetryAfter time.Duration `json:"retry_after,omitempty"`
 
+	// Until is when the ban is lifted (zero if unknown)
+	Until time.Time `json:"until,omitzero"`
+
 	// Message is a human-readable error message
 	Message string `json:"message"`
 }
@@ -83,11 +86,16 @@ func (e *IPBanError) IsRetryable() bool {
 }
 
 // NewIPBanError creates a new IPBanError.
+// Until is set from retryAfter when it is positive.
 func NewIPBanError(exchange, reason string, retryAfter time.Duration) *IPBanError {
-	return &IPBanError{
+	err := &IPBanError{
 		Exchange:   exchange,
 		Reason:     reason,
 		RetryAfter: retryAfter,
 		Message:    "IP banned",
 	}
+	if retryAfter > 0 {
+		err.Until = time.Now().Add(retryAfter)
+	}
+	return err
 }